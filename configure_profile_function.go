@@ -0,0 +1,226 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_configure_profile_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"duckdb"
+	"runtime"
+	"unsafe"
+)
+
+// duckarrow_configure_profile_callback is the scalar function callback for
+// duckarrow_configure_profile(name, uri, username, password, [skip_verify]).
+// It validates and stores a named connection profile, letting a single
+// session federate queries across several Flight SQL servers via
+// duckarrow_<profile>.tablename or duckarrow.<profile>.tablename references.
+//
+// Parameters:
+//   - info: Function execution context for error reporting
+//   - input: Data chunk containing four or five parameters:
+//   - name (VARCHAR): Profile name (required)
+//   - uri (VARCHAR): gRPC URI (required)
+//   - username (VARCHAR): Authentication username (optional, can be empty)
+//   - password (VARCHAR): Authentication password (optional, can be empty)
+//   - skip_verify (BOOLEAN): Skip TLS certificate verification (optional, defaults to false)
+//   - output: Output vector for the result message
+//
+// Thread safety: Uses runtime.LockOSThread() as required for CGO callbacks.
+// The configuration is stored atomically via SetDuckArrowProfile().
+//
+//export duckarrow_configure_profile_callback
+func duckarrow_configure_profile_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Get input size
+	inputSize := C.duckdb_data_chunk_get_size(input)
+	if inputSize == 0 {
+		return
+	}
+
+	// Bounds check: DuckDB chunks should never exceed maxDuckDBChunkSize
+	if inputSize > maxDuckDBChunkSize {
+		setConfigureProfileError(info, "input chunk size exceeds maximum")
+		return
+	}
+
+	// Get the input vectors (name, uri, username, password, and optional skip_verify)
+	nameVec := C.duckdb_data_chunk_get_vector(input, 0)
+	uriVec := C.duckdb_data_chunk_get_vector(input, 1)
+	usernameVec := C.duckdb_data_chunk_get_vector(input, 2)
+	passwordVec := C.duckdb_data_chunk_get_vector(input, 3)
+
+	if nameVec == nil || uriVec == nil || usernameVec == nil || passwordVec == nil {
+		setConfigureProfileError(info, "failed to get input vectors")
+		return
+	}
+
+	// Get optional skip_verify vector (5th parameter)
+	columnCount := C.duckdb_data_chunk_get_column_count(input)
+	var skipVerifyVec C.duckdb_vector
+	if columnCount >= 5 {
+		skipVerifyVec = C.duckdb_data_chunk_get_vector(input, 4)
+	}
+
+	// Get data pointers for each vector
+	nameDataPtr := C.duckdb_vector_get_data(nameVec)
+	uriDataPtr := C.duckdb_vector_get_data(uriVec)
+	usernameDataPtr := C.duckdb_vector_get_data(usernameVec)
+	passwordDataPtr := C.duckdb_vector_get_data(passwordVec)
+
+	if nameDataPtr == nil || uriDataPtr == nil || usernameDataPtr == nil || passwordDataPtr == nil {
+		setConfigureProfileError(info, "failed to get input data")
+		return
+	}
+
+	// Get validity masks
+	nameValidity := C.duckdb_vector_get_validity(nameVec)
+	uriValidity := C.duckdb_vector_get_validity(uriVec)
+	usernameValidity := C.duckdb_vector_get_validity(usernameVec)
+	passwordValidity := C.duckdb_vector_get_validity(passwordVec)
+
+	// Process each row (typically just one for scalar functions)
+	for i := C.idx_t(0); i < inputSize; i++ {
+		// Check for NULL inputs - name and URI are required
+		if nameValidity != nil && !rowIsValid(nameValidity, uint64(i), uint64(inputSize)) {
+			C.duckdb_vector_ensure_validity_writable(output)
+			outValidity := C.duckdb_vector_get_validity(output)
+			if outValidity != nil {
+				setRowInvalid(outValidity, uint64(i), uint64(inputSize))
+			}
+			continue
+		}
+		if uriValidity != nil && !rowIsValid(uriValidity, uint64(i), uint64(inputSize)) {
+			C.duckdb_vector_ensure_validity_writable(output)
+			outValidity := C.duckdb_vector_get_validity(output)
+			if outValidity != nil {
+				setRowInvalid(outValidity, uint64(i), uint64(inputSize))
+			}
+			continue
+		}
+
+		// Extract profile name (required)
+		name, err := extractString(nameDataPtr, i)
+		if err != nil {
+			setConfigureProfileError(info, "failed to read profile name: "+err.Error())
+			return
+		}
+		if name == "" {
+			setConfigureProfileError(info, "profile name cannot be empty")
+			return
+		}
+
+		// Extract URI (required)
+		uri, err := extractString(uriDataPtr, i)
+		if err != nil {
+			setConfigureProfileError(info, "failed to read URI: "+err.Error())
+			return
+		}
+
+		// Validate URI
+		if err := validateURI(uri); err != nil {
+			setConfigureProfileError(info, err.Error())
+			return
+		}
+
+		// Extract username (use empty string if NULL)
+		var username string
+		if usernameValidity == nil || rowIsValid(usernameValidity, uint64(i), uint64(inputSize)) {
+			username, _ = extractString(usernameDataPtr, i)
+		}
+
+		// Extract password (use empty string if NULL)
+		var password string
+		if passwordValidity == nil || rowIsValid(passwordValidity, uint64(i), uint64(inputSize)) {
+			password, _ = extractString(passwordDataPtr, i)
+		}
+
+		// Extract skip_verify (default to false for security)
+		skipVerify := false
+		if skipVerifyVec != nil {
+			skipVerifyDataPtr := C.duckdb_vector_get_data(skipVerifyVec)
+			skipVerifyValidity := C.duckdb_vector_get_validity(skipVerifyVec)
+			if skipVerifyDataPtr != nil && (skipVerifyValidity == nil || rowIsValid(skipVerifyValidity, uint64(i), uint64(inputSize))) {
+				// Boolean is stored as uint8 (0 = false, non-zero = true)
+				boolPtr := (*C.uint8_t)(unsafe.Pointer(uintptr(skipVerifyDataPtr) + uintptr(i)))
+				skipVerify = *boolPtr != 0
+			}
+		}
+
+		// Set the named profile configuration
+		SetDuckArrowProfile(name, uri, username, password, skipVerify)
+
+		// Return a confirmation message
+		duckdb.AssignStringToVector(duckdb.Vector{Ptr: unsafe.Pointer(output)}, int(i), "DuckArrow profile configured successfully")
+	}
+}
+
+// setConfigureProfileError is a helper to set an error on the
+// configure_profile function with consistent formatting.
+func setConfigureProfileError(info C.duckdb_function_info, msg string) {
+	errMsg := C.CString("duckarrow_configure_profile: " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// RegisterDuckArrowConfigureProfileFunction registers the
+// duckarrow_configure_profile(name, uri, username, password, [skip_verify])
+// scalar function. This lets a session configure additional named Flight SQL
+// endpoints beyond the default profile, queryable via
+// duckarrow_<profile>.tablename or duckarrow.<profile>.tablename.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_configure_profile('analytics', 'grpc+tls://analytics:31337', 'user', 'pass');
+//	SELECT * FROM duckarrow_analytics.events;
+//	SELECT * FROM duckarrow.analytics.events;
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowConfigureProfileFunction(conn duckdb.Connection) duckdb.State {
+	// Create scalar function
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	// Set name
+	name := C.CString("duckarrow_configure_profile")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	// Add four required VARCHAR parameters (profile name, URI, username, password)
+	varcharType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // name
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // URI
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // username
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // password
+	C.duckdb_destroy_logical_type(&varcharType)
+
+	// Add optional BOOLEAN varargs for skip_verify (allows 0 or more boolean arguments)
+	boolType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_BOOLEAN)
+	C.duckdb_scalar_function_set_varargs(scalarFunc, boolType)
+	C.duckdb_destroy_logical_type(&boolType)
+
+	// Set VARCHAR return type
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	// Set the callback
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_configure_profile_callback))
+
+	// Register the function
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
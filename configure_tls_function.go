@@ -0,0 +1,197 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_configure_tls_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"duckdb"
+	"runtime"
+	"unsafe"
+)
+
+// duckarrow_configure_tls_callback is the scalar function callback for
+// duckarrow_configure_tls(uri, cert, key, ca, [bearer]). It configures the
+// default profile for mTLS and/or bearer-token authentication, for talking
+// to Flight SQL servers that don't accept username/password (e.g. Dremio,
+// Ballista).
+//
+// Parameters:
+//   - info: Function execution context for error reporting
+//   - input: Data chunk containing four or five parameters:
+//   - uri (VARCHAR): gRPC URI (required)
+//   - cert (VARCHAR): Path to the client certificate PEM file (optional, can be empty)
+//   - key (VARCHAR): Path to the client private key PEM file (optional, can be empty)
+//   - ca (VARCHAR): Path to the CA certificate PEM file (optional, can be empty)
+//   - bearer (VARCHAR): Bearer token sent as "authorization: Bearer <token>" (optional)
+//   - output: Output vector for the result message
+//
+// Thread safety: Uses runtime.LockOSThread() as required for CGO callbacks.
+// The configuration is stored atomically via SetDuckArrowProfileConfig().
+//
+//export duckarrow_configure_tls_callback
+func duckarrow_configure_tls_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	inputSize := C.duckdb_data_chunk_get_size(input)
+	if inputSize == 0 {
+		return
+	}
+	if inputSize > maxDuckDBChunkSize {
+		setConfigureTLSError(info, "input chunk size exceeds maximum")
+		return
+	}
+
+	uriVec := C.duckdb_data_chunk_get_vector(input, 0)
+	certVec := C.duckdb_data_chunk_get_vector(input, 1)
+	keyVec := C.duckdb_data_chunk_get_vector(input, 2)
+	caVec := C.duckdb_data_chunk_get_vector(input, 3)
+
+	if uriVec == nil || certVec == nil || keyVec == nil || caVec == nil {
+		setConfigureTLSError(info, "failed to get input vectors")
+		return
+	}
+
+	// Get optional bearer token vector (5th parameter)
+	columnCount := C.duckdb_data_chunk_get_column_count(input)
+	var bearerVec C.duckdb_vector
+	if columnCount >= 5 {
+		bearerVec = C.duckdb_data_chunk_get_vector(input, 4)
+	}
+
+	uriDataPtr := C.duckdb_vector_get_data(uriVec)
+	certDataPtr := C.duckdb_vector_get_data(certVec)
+	keyDataPtr := C.duckdb_vector_get_data(keyVec)
+	caDataPtr := C.duckdb_vector_get_data(caVec)
+
+	if uriDataPtr == nil || certDataPtr == nil || keyDataPtr == nil || caDataPtr == nil {
+		setConfigureTLSError(info, "failed to get input data")
+		return
+	}
+
+	uriValidity := C.duckdb_vector_get_validity(uriVec)
+	certValidity := C.duckdb_vector_get_validity(certVec)
+	keyValidity := C.duckdb_vector_get_validity(keyVec)
+	caValidity := C.duckdb_vector_get_validity(caVec)
+
+	for i := C.idx_t(0); i < inputSize; i++ {
+		if uriValidity != nil && !rowIsValid(uriValidity, uint64(i), uint64(inputSize)) {
+			C.duckdb_vector_ensure_validity_writable(output)
+			outValidity := C.duckdb_vector_get_validity(output)
+			if outValidity != nil {
+				setRowInvalid(outValidity, uint64(i), uint64(inputSize))
+			}
+			continue
+		}
+
+		uri, err := extractString(uriDataPtr, i)
+		if err != nil {
+			setConfigureTLSError(info, "failed to read URI: "+err.Error())
+			return
+		}
+		if err := validateURI(uri); err != nil {
+			setConfigureTLSError(info, err.Error())
+			return
+		}
+
+		var cert string
+		if certValidity == nil || rowIsValid(certValidity, uint64(i), uint64(inputSize)) {
+			cert, _ = extractString(certDataPtr, i)
+		}
+		var key string
+		if keyValidity == nil || rowIsValid(keyValidity, uint64(i), uint64(inputSize)) {
+			key, _ = extractString(keyDataPtr, i)
+		}
+		var ca string
+		if caValidity == nil || rowIsValid(caValidity, uint64(i), uint64(inputSize)) {
+			ca, _ = extractString(caDataPtr, i)
+		}
+
+		var bearer string
+		if bearerVec != nil {
+			bearerDataPtr := C.duckdb_vector_get_data(bearerVec)
+			bearerValidity := C.duckdb_vector_get_validity(bearerVec)
+			if bearerDataPtr != nil && (bearerValidity == nil || rowIsValid(bearerValidity, uint64(i), uint64(inputSize))) {
+				bearer, _ = extractString(bearerDataPtr, i)
+			}
+		}
+
+		// Overlay only the fields this call supplies onto the default
+		// profile's existing config, rather than replacing it outright:
+		// duckarrow_configure_tls is meant to compose with
+		// duckarrow_configure/duckarrow_configure_profile, so a call to one
+		// shouldn't silently wipe fields only the other one sets (e.g.
+		// Username/Password here, or TLS/bearer fields the other way).
+		cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+		cfg.URI = uri
+		cfg.TLSClientCert = cert
+		cfg.TLSClientKey = key
+		cfg.TLSCACert = ca
+		cfg.BearerToken = bearer
+		SetDuckArrowProfileConfig(defaultProfileName, cfg)
+
+		duckdb.AssignStringToVector(duckdb.Vector{Ptr: unsafe.Pointer(output)}, int(i), "DuckArrow TLS configured successfully")
+	}
+}
+
+// setConfigureTLSError is a helper to set an error on the configure_tls
+// function with consistent formatting.
+func setConfigureTLSError(info C.duckdb_function_info, msg string) {
+	errMsg := C.CString("duckarrow_configure_tls: " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// RegisterDuckArrowConfigureTLSFunction registers the
+// duckarrow_configure_tls(uri, cert, key, ca, [bearer]) scalar function, used
+// to register mTLS and/or bearer-token Flight SQL endpoints (e.g. Dremio,
+// Ballista) that don't accept username/password.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_configure_tls('grpc+tls://dremio:31337', '/etc/duckarrow/client.pem', '/etc/duckarrow/client.key', '/etc/duckarrow/ca.pem');
+//	SELECT duckarrow_configure_tls('grpc+tls://ballista:31337', '', '', '', 'my-bearer-token');
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowConfigureTLSFunction(conn duckdb.Connection) duckdb.State {
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	name := C.CString("duckarrow_configure_tls")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	varcharType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // uri
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // cert
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // key
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // ca
+	C.duckdb_destroy_logical_type(&varcharType)
+
+	// Add optional VARCHAR varargs for the bearer token (allows 0 or more extra
+	// arguments; only the first one is read, as the bearer token)
+	varcharVarargType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_varargs(scalarFunc, varcharVarargType)
+	C.duckdb_destroy_logical_type(&varcharVarargType)
+
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_configure_tls_callback))
+
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
@@ -0,0 +1,251 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_execute_batch_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"context"
+	"duckdb"
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"main/internal/flight"
+	"main/internal/tracing"
+)
+
+// maxBatchStatements bounds how many statements a single
+// duckarrow_execute_batch call may carry, to keep one scalar function
+// invocation from unbounded server-side work.
+const maxBatchStatements = 10_000
+
+// duckarrow_execute_batch_callback is the scalar function callback for
+// duckarrow_execute_batch(sql_list). It executes every statement in
+// sql_list, in order, on a single ADBC statement (see Client.ExecuteBatch),
+// and returns one affected-row-count per statement. Useful for schema
+// migrations and bulk DDL/DML, where duckarrow_execute's per-call
+// connection-pool round trip otherwise dominates.
+//
+// Parameters:
+//   - info: Function execution context for error reporting
+//   - input: Data chunk containing one parameter:
+//   - sql_list (VARCHAR[]): statements to execute in order (required)
+//   - output: Output vector of BIGINT[], one affected-row-count per statement
+//
+// Thread safety: Uses runtime.LockOSThread() as required for CGO callbacks.
+//
+//export duckarrow_execute_batch_callback
+func duckarrow_execute_batch_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.execute_batch")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	inputSize := C.duckdb_data_chunk_get_size(input)
+	if inputSize == 0 {
+		return
+	}
+	if inputSize > maxDuckDBChunkSize {
+		setExecuteBatchError(info, "input chunk size exceeds maximum")
+		return
+	}
+
+	listVec := C.duckdb_data_chunk_get_vector(input, 0)
+	if listVec == nil {
+		setExecuteBatchError(info, "failed to get input vector")
+		return
+	}
+	listValidity := C.duckdb_vector_get_validity(listVec)
+
+	listEntries := unsafe.Slice((*C.duckdb_list_entry)(C.duckdb_vector_get_data(listVec)), inputSize)
+	childVec := C.duckdb_list_vector_get_child(listVec)
+	childDataPtr := C.duckdb_vector_get_data(childVec)
+	childValidity := C.duckdb_vector_get_validity(childVec)
+	if childDataPtr == nil {
+		setExecuteBatchError(info, "failed to get input data")
+		return
+	}
+
+	cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+	if cfg.URI == "" {
+		opErr = errors.New("not configured")
+		setExecuteBatchError(info, "not configured - call duckarrow_configure() first")
+		return
+	}
+	span.SetAttributes(attribute.String("uri", cfg.URI))
+
+	threadID := currentThreadID()
+	connResult, pinned := flight.PinnedTransaction(threadID)
+	if !pinned {
+		var err error
+		connResult, err = flight.GetConnection(ctx, cfg)
+		if err != nil {
+			opErr = err
+			setExecuteBatchError(info, "connection failed: "+err.Error())
+			return
+		}
+		defer func() {
+			if connResult.IsPooled {
+				flight.ReleaseConnection(connResult.Lease)
+			} else {
+				connResult.Client.Close()
+			}
+		}()
+	}
+
+	outEntries := unsafe.Slice((*C.duckdb_list_entry)(C.duckdb_vector_get_data(output)), inputSize)
+	outChildVec := C.duckdb_list_vector_get_child(output)
+	flatOffset := 0
+
+	for i := C.idx_t(0); i < inputSize; i++ {
+		if listValidity != nil && !rowIsValid(listValidity, uint64(i), uint64(inputSize)) {
+			C.duckdb_vector_ensure_validity_writable(output)
+			outValidity := C.duckdb_vector_get_validity(output)
+			if outValidity != nil {
+				setRowInvalid(outValidity, uint64(i), uint64(inputSize))
+			}
+			continue
+		}
+
+		entry := listEntries[i]
+		sqls, err := readBatchStatements(childDataPtr, childValidity, entry)
+		if err != nil {
+			setExecuteBatchError(info, err.Error())
+			return
+		}
+
+		results, err := connResult.Client.ExecuteBatch(ctx, sqls)
+		if err != nil {
+			opErr = err
+			setExecuteBatchError(info, "remote server: "+err.Error())
+			return
+		}
+
+		C.duckdb_list_vector_reserve(outChildVec, C.idx_t(flatOffset+len(results)))
+		outChildData := unsafe.Slice((*C.int64_t)(C.duckdb_vector_get_data(outChildVec)), flatOffset+len(results))
+		for j, affected := range results {
+			outChildData[flatOffset+j] = C.int64_t(affected)
+		}
+		outEntries[i] = C.duckdb_list_entry{offset: C.idx_t(flatOffset), length: C.idx_t(len(results))}
+		flatOffset += len(results)
+	}
+
+	C.duckdb_list_vector_set_size(output, C.idx_t(flatOffset))
+}
+
+// readBatchStatements reads entry's statements out of a VARCHAR[] child
+// vector, applying the same validation duckarrow_execute applies to its own
+// sql parameter to every element.
+func readBatchStatements(childDataPtr unsafe.Pointer, childValidity *C.uint64_t, entry C.duckdb_list_entry) ([]string, error) {
+	if entry.length == 0 {
+		return nil, fmt.Errorf("statement list must not be empty")
+	}
+	if uint64(entry.length) > maxBatchStatements {
+		return nil, fmt.Errorf("statement list exceeds maximum of %d entries", maxBatchStatements)
+	}
+
+	const maxSQLLength = 1024 * 1024
+
+	sqls := make([]string, entry.length)
+	for j := C.idx_t(0); j < entry.length; j++ {
+		idx := entry.offset + j
+		if childValidity != nil && !listChildRowIsValid(childValidity, uint64(idx)) {
+			return nil, fmt.Errorf("statement %d is NULL", int(j))
+		}
+
+		sql, err := extractString(childDataPtr, idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read statement %d: %w", int(j), err)
+		}
+		if sql == "" {
+			return nil, fmt.Errorf("statement %d must not be empty", int(j))
+		}
+		if len(sql) > maxSQLLength {
+			return nil, fmt.Errorf("statement %d exceeds maximum length (1MB)", int(j))
+		}
+		for _, c := range sql {
+			if c == 0 {
+				return nil, fmt.Errorf("statement %d contains invalid null byte", int(j))
+			}
+		}
+
+		sqls[j] = sql
+	}
+
+	return sqls, nil
+}
+
+// listChildRowIsValid checks if idx is valid (not NULL) in a LIST vector's
+// child validity mask. Unlike rowIsValid, which assumes a top-level vector
+// capped at maxDuckDBChunkSize rows, a list child vector holds the flattened
+// elements of every row in the chunk and can be far larger.
+func listChildRowIsValid(validity *C.uint64_t, idx uint64) bool {
+	if validity == nil {
+		return true
+	}
+	entryIdx := idx / 64
+	idxInEntry := idx % 64
+	validitySlice := unsafe.Slice(validity, entryIdx+1)
+	return (validitySlice[entryIdx] & (1 << idxInEntry)) != 0
+}
+
+// setExecuteBatchError is a helper to set an error on the execute_batch
+// function with consistent formatting.
+func setExecuteBatchError(info C.duckdb_function_info, msg string) {
+	errMsg := C.CString("duckarrow_execute_batch: " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// RegisterDuckArrowExecuteBatchFunction registers the
+// duckarrow_execute_batch(sql_list) scalar function. This function allows
+// users to execute a list of DDL/DML statements on the Flight SQL server
+// within a single pooled connection, in order.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_execute_batch(['CREATE TABLE a (id INTEGER)', 'CREATE TABLE b (id INTEGER)']);
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowExecuteBatchFunction(conn duckdb.Connection) duckdb.State {
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	name := C.CString("duckarrow_execute_batch")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	varcharType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	varcharListType := C.duckdb_create_list_type(varcharType)
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharListType)
+	C.duckdb_destroy_logical_type(&varcharListType)
+	C.duckdb_destroy_logical_type(&varcharType)
+
+	bigintType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_BIGINT)
+	bigintListType := C.duckdb_create_list_type(bigintType)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, bigintListType)
+	C.duckdb_destroy_logical_type(&bigintListType)
+	C.duckdb_destroy_logical_type(&bigintType)
+
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_execute_batch_callback))
+
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
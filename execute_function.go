@@ -13,19 +13,30 @@ import "C"
 import (
 	"context"
 	"duckdb"
+	"errors"
+	"fmt"
 	"runtime"
 	"unsafe"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"main/internal/flight"
+	"main/internal/tracing"
 )
 
-// duckarrow_execute_callback is the scalar function callback for duckarrow_execute(sql).
-// It executes DDL/DML statements on the configured Flight SQL server.
+// duckarrow_execute_callback is the scalar function callback for
+// duckarrow_execute(sql, [params...]). It executes DDL/DML statements on the
+// configured Flight SQL server, optionally as a server-side prepared
+// statement bound against the trailing variadic arguments - e.g.
+// duckarrow_execute('INSERT INTO t VALUES (?, ?)', 42, 'foo').
 //
 // Parameters:
 //   - info: Function execution context for error reporting
-//   - input: Data chunk containing one parameter:
+//   - input: Data chunk containing one required parameter plus any number of
+//     bind parameters:
 //   - sql (VARCHAR): SQL statement to execute (required)
+//   - params (ANY, variadic): values bound against sql's "?" placeholders,
+//     in argument order (optional)
 //   - output: Output vector for the affected row count (BIGINT)
 //
 // Thread safety: Uses runtime.LockOSThread() as required for CGO callbacks.
@@ -35,6 +46,10 @@ func duckarrow_execute_callback(info C.duckdb_function_info, input C.duckdb_data
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.execute")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
 	// Get input size
 	inputSize := C.duckdb_data_chunk_get_size(input)
 	if inputSize == 0 {
@@ -64,40 +79,56 @@ func duckarrow_execute_callback(info C.duckdb_function_info, input C.duckdb_data
 	// Get validity mask
 	sqlValidity := C.duckdb_vector_get_validity(sqlVec)
 
+	// Any columns beyond the first are bind parameters for sql's "?"
+	// placeholders, one value per extra argument the call passed.
+	columnCount := int(C.duckdb_data_chunk_get_column_count(input))
+	paramVecs := make([]C.duckdb_vector, columnCount-1)
+	for i := range paramVecs {
+		paramVecs[i] = C.duckdb_data_chunk_get_vector(input, C.idx_t(i+1))
+	}
+
 	// Get output data pointer (BIGINT = int64)
 	outputDataPtr := (*C.int64_t)(C.duckdb_vector_get_data(output))
 	outputData := unsafe.Slice(outputDataPtr, inputSize)
 
-	// Get config for connection
-	uri, username, password, skipVerify := GetDuckArrowConfig()
-	if uri == "" {
+	// Get config (including any mTLS/bearer-token settings) for connection
+	cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+	if cfg.URI == "" {
+		opErr = errors.New("not configured")
 		setExecuteError(info, "not configured - call duckarrow_configure() first")
 		return
 	}
+	span.SetAttributes(attribute.String("uri", cfg.URI))
 
-	// Build config for connection pool
-	cfg := flight.Config{
-		URI:        uri,
-		Username:   username,
-		Password:   password,
-		SkipVerify: skipVerify,
+	// If duckarrow_begin pinned a transaction to this thread, reuse that
+	// connection instead of borrowing a fresh one so this statement joins
+	// the open transaction; it's released by duckarrow_commit/
+	// duckarrow_rollback (or the pool's abandoned-transaction reaper), not here.
+	threadID := currentThreadID()
+	connResult, pinned := flight.PinnedTransaction(threadID)
+	if !pinned {
+		var err error
+		connResult, err = flight.GetConnection(ctx, cfg)
+		if err != nil {
+			opErr = err
+			setExecuteError(info, "connection failed: "+err.Error())
+			return
+		}
+		defer func() {
+			if connResult.IsPooled {
+				flight.ReleaseConnection(connResult.Lease)
+			} else {
+				connResult.Client.Close()
+			}
+		}()
 	}
 
-	// Get connection from pool
-	ctx := context.Background()
-	connResult, err := flight.GetConnection(ctx, cfg)
-	if err != nil {
-		setExecuteError(info, "connection failed: "+err.Error())
-		return
-	}
-	// Defer connection release/close
-	defer func() {
-		if connResult.IsPooled {
-			flight.ReleaseConnection(cfg)
-		} else {
-			connResult.Client.Close()
-		}
-	}()
+	// lastAffected tracks the most recent row's affected count for the
+	// rows_affected span attribute below. outputData[inputSize-1] isn't safe
+	// to read directly for that: the loop below continues past NULL-SQL rows
+	// without writing outputData[i], so if the last row has a NULL sql
+	// argument that slot is never written.
+	var lastAffected int64
 
 	// Process each row (typically just one for scalar functions)
 	for i := C.idx_t(0); i < inputSize; i++ {
@@ -138,16 +169,50 @@ func duckarrow_execute_callback(info C.duckdb_function_info, input C.duckdb_data
 			}
 		}
 
-		// Execute the statement on remote Flight SQL server
-		affected, err := connResult.Client.Execute(ctx, sql)
+		// Extract bind parameters, if any, for this row.
+		var params []any
+		if len(paramVecs) > 0 {
+			params = make([]any, len(paramVecs))
+			for pi, vec := range paramVecs {
+				v, err := extractVarargValue(vec, i, inputSize)
+				if err != nil {
+					setExecuteError(info, fmt.Sprintf("failed to read parameter %d: %v", pi+1, err))
+					return
+				}
+				params[pi] = v
+			}
+		}
+
+		// Rewrite "?" placeholders into the positional "$N" form
+		// ExecuteUpdatePrepared's bind record expects. Named (":name")
+		// placeholders aren't supported here since scalar function arguments
+		// are positional only, with no argument names to resolve them against.
+		execSQL := sql
+		if len(params) > 0 {
+			rewritten, names := flight.ParseQueryParams(sql)
+			if len(names) > 0 {
+				setExecuteError(info, "named (\":name\") placeholders are not supported by duckarrow_execute; use \"?\"")
+				return
+			}
+			execSQL = rewritten
+		}
+
+		// Execute the statement on remote Flight SQL server. Goes through the
+		// prepared-statement cache so repeated calls with the same SQL text
+		// (e.g. the same UDF invocation across many rows) skip re-parsing and
+		// re-planning on the server.
+		affected, err := connResult.Client.ExecuteUpdatePrepared(ctx, execSQL, params)
 		if err != nil {
+			opErr = err
 			setExecuteError(info, "remote server: "+err.Error())
 			return
 		}
 
 		// Return the affected row count
 		outputData[i] = C.int64_t(affected)
+		lastAffected = affected
 	}
+	span.SetAttributes(attribute.Int64("rows_affected", lastAffected))
 }
 
 // setExecuteError is a helper to set an error on the execute function with consistent formatting.
@@ -157,8 +222,61 @@ func setExecuteError(info C.duckdb_function_info, msg string) {
 	C.free(unsafe.Pointer(errMsg))
 }
 
-// RegisterDuckArrowExecuteFunction registers the duckarrow_execute(sql) scalar function.
-// This function allows users to execute DDL/DML statements on the Flight SQL server.
+// extractVarargValue reads the value of vec at rowIdx into the Go type
+// buildParamRecord (internal/flight) knows how to bind: string, bool,
+// float64, or int64/uint64. vec's declared type is read at runtime via
+// duckdb_vector_get_column_type since duckarrow_execute's bind parameters are
+// registered as DUCKDB_TYPE_ANY varargs, so each one can carry a different
+// concrete type depending on what the caller passed.
+func extractVarargValue(vec C.duckdb_vector, rowIdx, chunkSize C.idx_t) (any, error) {
+	validity := C.duckdb_vector_get_validity(vec)
+	if validity != nil && !rowIsValid(validity, uint64(rowIdx), uint64(chunkSize)) {
+		return nil, nil
+	}
+
+	ltype := C.duckdb_vector_get_column_type(vec)
+	defer C.duckdb_destroy_logical_type(&ltype)
+	typeID := C.duckdb_get_type_id(ltype)
+
+	dataPtr := C.duckdb_vector_get_data(vec)
+	if dataPtr == nil {
+		return nil, fmt.Errorf("failed to get parameter data")
+	}
+
+	switch typeID {
+	case C.DUCKDB_TYPE_VARCHAR:
+		return extractString(dataPtr, rowIdx)
+	case C.DUCKDB_TYPE_BOOLEAN:
+		return bool(unsafe.Slice((*C.bool)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_TINYINT:
+		return int64(unsafe.Slice((*C.int8_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_SMALLINT:
+		return int64(unsafe.Slice((*C.int16_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_INTEGER:
+		return int64(unsafe.Slice((*C.int32_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_BIGINT:
+		return int64(unsafe.Slice((*C.int64_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_UTINYINT:
+		return uint64(unsafe.Slice((*C.uint8_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_USMALLINT:
+		return uint64(unsafe.Slice((*C.uint16_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_UINTEGER:
+		return uint64(unsafe.Slice((*C.uint32_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_UBIGINT:
+		return uint64(unsafe.Slice((*C.uint64_t)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_FLOAT:
+		return float64(unsafe.Slice((*C.float)(dataPtr), chunkSize)[rowIdx]), nil
+	case C.DUCKDB_TYPE_DOUBLE:
+		return float64(unsafe.Slice((*C.double)(dataPtr), chunkSize)[rowIdx]), nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type (duckdb type id %d)", int(typeID))
+	}
+}
+
+// RegisterDuckArrowExecuteFunction registers the
+// duckarrow_execute(sql, [params...]) scalar function. This function allows
+// users to execute DDL/DML statements on the Flight SQL server, optionally as
+// a server-side prepared statement bound against params.
 //
 // The function is intended for statements that don't return result sets:
 //   - DDL: CREATE TABLE, DROP TABLE, ALTER TABLE, etc.
@@ -171,12 +289,14 @@ func setExecuteError(info C.duckdb_function_info, msg string) {
 //   - SQL is executed on the remote Flight SQL server with the configured credentials
 //   - Maximum SQL length is 1MB to prevent resource exhaustion
 //   - Null bytes are rejected to prevent truncation attacks
+//   - Passing values as bind parameters rather than interpolating them into
+//     the SQL text avoids SQL injection
 //
 // Usage in SQL:
 //
 //	SELECT duckarrow_execute('DROP TABLE "my_table"');
 //	SELECT duckarrow_execute('CREATE TABLE test (id INTEGER)');
-//	SELECT duckarrow_execute('INSERT INTO test VALUES (1)');
+//	SELECT duckarrow_execute('INSERT INTO test VALUES (?, ?)', 42, 'foo');
 //
 // Parameters:
 //   - conn: Active DuckDB connection for function registration
@@ -198,6 +318,12 @@ func RegisterDuckArrowExecuteFunction(conn duckdb.Connection) duckdb.State {
 	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType)
 	C.duckdb_destroy_logical_type(&varcharType)
 
+	// Add optional ANY varargs for bind parameters (allows 0 or more extra
+	// arguments), since "?" placeholders can be bound against any DuckDB type.
+	anyVarargType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_ANY)
+	C.duckdb_scalar_function_set_varargs(scalarFunc, anyVarargType)
+	C.duckdb_destroy_logical_type(&anyVarargType)
+
 	// Set BIGINT return type (affected row count)
 	bigintType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_BIGINT)
 	C.duckdb_scalar_function_set_return_type(scalarFunc, bigintType)
@@ -0,0 +1,254 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -I${SRCDIR}/cpp -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <string.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+#include "go_callbacks.h"
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"main/internal/flight"
+	"main/internal/tracing"
+)
+
+// queryParamArg is one flight_query bind argument decoded from the C side:
+// an already-stringified literal plus the DuckDB type it was read as,
+// mirroring how pushed filter literals arrive in decodePredicates. Name is
+// empty for a plain positional argument and set for a `name := value`
+// keyword argument, used to resolve ":name" placeholders in the SQL.
+type queryParamArg struct {
+	Name     string
+	TypeName string
+	Literal  string
+	IsNull   bool
+}
+
+// decodeQueryParams copies the parallel C arrays duckarrow_go_query_bind
+// receives for its variadic bind arguments into Go values.
+func decodeQueryParams(names, typeNames, literals **C.char, nulls *C.bool, count int) []queryParamArg {
+	if count <= 0 {
+		return nil
+	}
+
+	nameSlice := unsafe.Slice(names, count)
+	typeSlice := unsafe.Slice(typeNames, count)
+	litSlice := unsafe.Slice(literals, count)
+	nullSlice := unsafe.Slice(nulls, count)
+
+	args := make([]queryParamArg, count)
+	for i := 0; i < count; i++ {
+		a := queryParamArg{IsNull: bool(nullSlice[i])}
+		if nameSlice[i] != nil {
+			a.Name = C.GoString(nameSlice[i])
+		}
+		if typeSlice[i] != nil {
+			a.TypeName = C.GoString(typeSlice[i])
+		}
+		if litSlice[i] != nil {
+			a.Literal = C.GoString(litSlice[i])
+		}
+		args[i] = a
+	}
+	return args
+}
+
+// queryParamValue converts one decoded bind argument into the typed Go
+// value buildParamRecord expects, using the same DuckDB type vocabulary
+// arrowTypeToString/formatPredicateLiteral already use for pushed filters.
+//
+// Date/time/decimal types aren't in this switch: unlike a pushed-down
+// filter, a bind parameter has no SQL text of its own to wrap in a CAST(...)
+// the way formatPredicateLiteral does, and buildParamRecord only infers
+// string/bool/float/int Arrow types from a Go value. Those types fall
+// through to the default and are bound as plain strings, which only works
+// if the remote server coerces a VARCHAR bind against the target column.
+func queryParamValue(arg queryParamArg) (any, error) {
+	if arg.IsNull {
+		return nil, nil
+	}
+	switch strings.ToUpper(arg.TypeName) {
+	case "BOOLEAN":
+		return strconv.ParseBool(arg.Literal)
+	case "BIGINT", "INTEGER", "SMALLINT", "TINYINT":
+		return strconv.ParseInt(arg.Literal, 10, 64)
+	case "UBIGINT", "UINTEGER", "USMALLINT", "UTINYINT":
+		// Parsed as uint64 rather than ParseInt so values above
+		// math.MaxInt64 - valid for these unsigned types - still parse;
+		// toInt64 (client.go) accepts a uint64 Go value directly.
+		return strconv.ParseUint(arg.Literal, 10, 64)
+	case "DOUBLE", "FLOAT":
+		return strconv.ParseFloat(arg.Literal, 64)
+	default:
+		return arg.Literal, nil
+	}
+}
+
+// resolveQueryParams rewrites sql's placeholders via flight.ParseQueryParams
+// and orders args to match: named placeholders (":name") are looked up by
+// name in args, while "?"/"$N" placeholders are bound in the order args were
+// passed. Returns an error naming a named placeholder that has no matching
+// arg, or the name/position of an arg whose literal fails to parse. For
+// "?"/"$N" placeholders the number of args is not checked against the number
+// of placeholders in the SQL.
+func resolveQueryParams(sql string, args []queryParamArg) (rewritten string, params []any, err error) {
+	rewritten, names := flight.ParseQueryParams(sql)
+
+	if len(names) == 0 {
+		params = make([]any, len(args))
+		for i, a := range args {
+			v, convErr := queryParamValue(a)
+			if convErr != nil {
+				return "", nil, fmt.Errorf("parameter %d: %w", i+1, convErr)
+			}
+			params[i] = v
+		}
+		return rewritten, params, nil
+	}
+
+	byName := make(map[string]queryParamArg, len(args))
+	for _, a := range args {
+		byName[a.Name] = a
+	}
+
+	params = make([]any, len(names))
+	for i, name := range names {
+		a, ok := byName[name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing value for named parameter %q", name)
+		}
+		v, convErr := queryParamValue(a)
+		if convErr != nil {
+			return "", nil, fmt.Errorf("parameter %q: %w", name, convErr)
+		}
+		params[i] = v
+	}
+	return rewritten, params, nil
+}
+
+// duckarrow_go_query_bind binds an arbitrary SQL query for the
+// flight_query(sql, ...) table function - the companion to
+// duckarrow_go_scan_table_bind for joins, aggregations, and CTEs that don't
+// fit the whole-table-scan shape a replacement scan produces. paramNames,
+// paramTypes, paramLiterals and paramNulls are parallel arrays, one entry
+// per extra argument the call passed (empty paramNames entries are plain
+// positional arguments; non-empty ones are `name := value` keyword
+// arguments used to resolve ":name" placeholders).
+//
+// Column metadata is discovered the same way duckarrow_go_scan_table_bind
+// does - a WHERE-1=0 round trip - except here the user's query is wrapped
+// as a subquery rather than selecting straight from a named table, since
+// the bind has no table name to work with, only the query text.
+//
+//export duckarrow_go_query_bind
+func duckarrow_go_query_bind(
+	uri *C.char, sql *C.char,
+	paramNames **C.char, paramTypes **C.char, paramLiterals **C.char, paramNulls *C.bool, paramCount C.size_t,
+) C.DuckArrowScanBindResult {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var result C.DuckArrowScanBindResult
+	result.handle = nil
+	result.error = nil
+	result.column_count = 0
+
+	if uri == nil || sql == nil {
+		result.error = C.CString("duckarrow_go_query_bind: uri and sql are required")
+		return result
+	}
+
+	uriStr := C.GoString(uri)
+	sqlStr := C.GoString(sql)
+
+	args := decodeQueryParams(paramNames, paramTypes, paramLiterals, paramNulls, int(paramCount))
+	rewrittenSQL, params, err := resolveQueryParams(sqlStr, args)
+	if err != nil {
+		result.error = C.CString(fmt.Sprintf("duckarrow_go_query_bind: %v", err))
+		return result
+	}
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.query_bind",
+		attribute.String("uri", uriStr), attribute.Int("param_count", len(params)))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	// Get credentials (including any mTLS/bearer-token settings) from the
+	// default profile's global config; only the URI is overridden per-call,
+	// matching duckarrow_go_scan_table_bind.
+	cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+	cfg.URI = uriStr
+
+	connResult, err := flight.GetConnection(ctx, cfg)
+	if err != nil {
+		opErr = err
+		result.error = C.CString(fmt.Sprintf("duckarrow_go_query_bind: connection failed: %v", err))
+		return result
+	}
+
+	// Discover columns by running the query itself rather than a schema
+	// round trip against a named table: wrap it as a subquery with
+	// WHERE 1=0 so no rows are actually fetched.
+	schemaQuery := fmt.Sprintf("SELECT * FROM (%s) AS duckarrow_query WHERE 1=0", rewrittenSQL)
+
+	var queryResult *flight.QueryResult
+	if len(params) > 0 {
+		queryResult, err = connResult.Client.QueryWithParams(ctx, schemaQuery, params)
+	} else {
+		queryResult, err = connResult.Client.Query(ctx, schemaQuery)
+	}
+	if err != nil {
+		opErr = err
+		if connResult.IsPooled {
+			flight.ReleaseConnection(connResult.Lease)
+		} else {
+			connResult.Client.Close()
+		}
+		result.error = C.CString(fmt.Sprintf("duckarrow_go_query_bind: schema query failed: %v", err))
+		return result
+	}
+
+	schema := queryResult.Reader.Schema()
+	columnNames := make([]string, len(schema.Fields()))
+	columnTypes := make([]string, len(schema.Fields()))
+	for i, field := range schema.Fields() {
+		columnNames[i] = field.Name
+		columnTypes[i] = arrowTypeToString(field.Type)
+	}
+
+	// Release schema query resources - the actual scan re-executes the
+	// query (with projection) in init, same as duckarrow_go_scan_table_bind.
+	queryResult.Reader.Release()
+	queryResult.Stmt.Close()
+
+	bindData := &ScanBindData{
+		Client:      connResult.Client,
+		Config:      cfg,
+		IsPooled:    connResult.IsPooled,
+		Lease:       connResult.Lease,
+		URI:         uriStr,
+		RawQuery:    rewrittenSQL,
+		Params:      params,
+		ColumnNames: columnNames,
+		ColumnTypes: columnTypes,
+		Schema:      schema,
+	}
+
+	handle := cgo.NewHandle(bindData)
+	result.handle = C.DuckArrowScanHandle(uintptr(handle))
+	result.column_count = C.size_t(len(columnNames))
+
+	return result
+}
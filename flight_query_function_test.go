@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryParamValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     queryParamArg
+		want    any
+		wantErr bool
+	}{
+		{name: "null", arg: queryParamArg{IsNull: true, TypeName: "BIGINT", Literal: "5"}, want: nil},
+		{name: "varchar", arg: queryParamArg{TypeName: "VARCHAR", Literal: "hello"}, want: "hello"},
+		{name: "bigint", arg: queryParamArg{TypeName: "BIGINT", Literal: "42"}, want: int64(42)},
+		{name: "double", arg: queryParamArg{TypeName: "DOUBLE", Literal: "3.5"}, want: 3.5},
+		{name: "boolean", arg: queryParamArg{TypeName: "BOOLEAN", Literal: "true"}, want: true},
+		{name: "invalid integer", arg: queryParamArg{TypeName: "BIGINT", Literal: "not-a-number"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queryParamValue(tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("queryParamValue(%+v) expected error, got nil", tt.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryParamValue(%+v) unexpected error: %v", tt.arg, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("queryParamValue(%+v) = %v, want %v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveQueryParams(t *testing.T) {
+	t.Run("positional question marks bind in call order", func(t *testing.T) {
+		sql := `SELECT * FROM "t" WHERE a = ? AND b = ?`
+		args := []queryParamArg{
+			{TypeName: "BIGINT", Literal: "1"},
+			{TypeName: "VARCHAR", Literal: "x"},
+		}
+
+		gotSQL, gotParams, err := resolveQueryParams(sql, args)
+		if err != nil {
+			t.Fatalf("resolveQueryParams: %v", err)
+		}
+		wantSQL := `SELECT * FROM "t" WHERE a = $1 AND b = $2`
+		if gotSQL != wantSQL {
+			t.Errorf("sql = %q, want %q", gotSQL, wantSQL)
+		}
+		wantParams := []any{int64(1), "x"}
+		if !reflect.DeepEqual(gotParams, wantParams) {
+			t.Errorf("params = %v, want %v", gotParams, wantParams)
+		}
+	})
+
+	t.Run("named placeholders resolved by name regardless of call order", func(t *testing.T) {
+		sql := `SELECT * FROM "t" WHERE a = :first AND b = :second`
+		args := []queryParamArg{
+			{Name: "second", TypeName: "VARCHAR", Literal: "x"},
+			{Name: "first", TypeName: "BIGINT", Literal: "1"},
+		}
+
+		gotSQL, gotParams, err := resolveQueryParams(sql, args)
+		if err != nil {
+			t.Fatalf("resolveQueryParams: %v", err)
+		}
+		wantSQL := `SELECT * FROM "t" WHERE a = $1 AND b = $2`
+		if gotSQL != wantSQL {
+			t.Errorf("sql = %q, want %q", gotSQL, wantSQL)
+		}
+		wantParams := []any{int64(1), "x"}
+		if !reflect.DeepEqual(gotParams, wantParams) {
+			t.Errorf("params = %v, want %v", gotParams, wantParams)
+		}
+	})
+
+	t.Run("missing named parameter is an error", func(t *testing.T) {
+		sql := `SELECT * FROM "t" WHERE a = :first`
+		if _, _, err := resolveQueryParams(sql, nil); err == nil {
+			t.Fatal("expected error for missing named parameter, got nil")
+		}
+	})
+}
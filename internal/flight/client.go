@@ -2,29 +2,95 @@ package flight
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/arrow-adbc/go/adbc"
 	"github.com/apache/arrow-adbc/go/adbc/driver/flightsql"
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	"main/internal/tracing"
 )
 
+// OAuth2TokenSource returns a bearer token to attach to an outgoing RPC. It
+// is invoked fresh for every call (not just once at Connect time) so tokens
+// that expire mid-session can be refreshed without tearing down and
+// re-establishing the Flight connection.
+type OAuth2TokenSource func(ctx context.Context) (string, error)
+
 // Config for DuckArrow Flight SQL connection
 type Config struct {
 	URI        string // e.g., "grpc+tls://localhost:31337"
 	Username   string
 	Password   string
 	SkipVerify bool
+
+	// BearerToken, if set, is sent as "authorization: Bearer <token>" on
+	// every RPC. OAuth2TokenSource takes precedence when both are set, since
+	// it allows the token to be refreshed; BearerToken is the static/simple
+	// case.
+	BearerToken       string
+	OAuth2TokenSource OAuth2TokenSource
+
+	// TLSCACert, TLSClientCert, and TLSClientKey are PEM file paths used to
+	// build an mTLS-capable transport. ServerNameOverride overrides the
+	// hostname validated against the server's certificate, which is useful
+	// when connecting through a load balancer or SSH tunnel.
+	TLSCACert          string
+	TLSClientCert      string
+	TLSClientKey       string
+	ServerNameOverride string
 }
 
 // Client wraps ADBC Flight SQL connection
 type Client struct {
-	db   adbc.Database
-	conn adbc.Connection
+	db       adbc.Database
+	conn     adbc.Connection
+	prepared *PreparedCache
+
+	// mu serializes every operation that touches conn or one of its
+	// statements, except ReadPartition: adbc.Connection/adbc.Statement
+	// aren't safe for concurrent use, but Pool's channel fan-out (see
+	// pool.go's Pool.Get) can hand the same Client to multiple concurrent
+	// callers once one channel is shared across leases. ReadPartition reads
+	// through its own Flight DoGet stream rather than conn or a statement
+	// and is documented safe to call concurrently; everything else (NewStatement,
+	// SetSqlQuery, Bind, Execute*, GetObjects, transaction control) must hold mu.
+	mu sync.Mutex
+
+	uri           string
+	username      string
+	metadataCache *LRUCache
+
+	// cfg is kept so ReadPartition can dial an independent alternate
+	// connection to race a slow DoGet against (see FetchFastest); every
+	// other Client method only ever needs conn/db, which is why this wasn't
+	// stored until that raced-alternate-connection need came up.
+	cfg Config
+}
+
+// WithMetadataCache attaches cache as c's catalog metadata cache, so
+// subsequent GetSchemas/GetTables/GetColumns calls memoize through it
+// instead of round-tripping to the Flight SQL server every time. Passing
+// nil disables caching for this client. Returns c so it can be chained onto
+// Connect's result.
+func (c *Client) WithMetadataCache(cache *LRUCache) *Client {
+	c.metadataCache = cache
+	return c
 }
 
 // Connect establishes connection to Flight SQL server
@@ -56,7 +122,41 @@ func Connect(ctx context.Context, cfg Config) (*Client, error) {
 		PermitWithoutStream: false,            // Only ping with active streams
 	})
 
-	db, err := drv.NewDatabaseWithOptions(opts, dialOpts, keepaliveOpts)
+	dialOptions := []grpc.DialOption{dialOpts, keepaliveOpts}
+
+	// mTLS/custom CA/SNI override is additive on top of the ADBC driver's own
+	// grpc+tls:// handling (which already covers plain SkipVerify). It only
+	// kicks in when the caller actually supplied cert material or an SNI
+	// override, so the common case is unaffected.
+	tlsCfg, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build tls config: %w", err)
+	}
+	if tlsCfg != nil {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	}
+
+	// Bearer/OAuth2 token auth is implemented as PerRPCCredentials rather
+	// than a plain header so OAuth2TokenSource is re-read on every call,
+	// letting refreshed tokens take effect without reconnecting.
+	if cfg.BearerToken != "" || cfg.OAuth2TokenSource != nil {
+		secure := tlsCfg != nil || cfg.SkipVerify || strings.HasPrefix(strings.ToLower(cfg.URI), "grpc+tls://")
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(perRPCToken{
+			static: cfg.BearerToken,
+			source: cfg.OAuth2TokenSource,
+			secure: secure,
+		}))
+	}
+
+	// Propagate the active trace context (and duckarrow's own spans) into
+	// outgoing Flight RPC metadata so a collector can correlate a DuckDB
+	// query with the gRPC calls it triggers.
+	dialOptions = append(dialOptions,
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor),
+	)
+
+	db, err := drv.NewDatabaseWithOptions(opts, dialOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("create database: %w", err)
 	}
@@ -67,18 +167,126 @@ func Connect(ctx context.Context, cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("open connection: %w", err)
 	}
 
-	return &Client{db: db, conn: conn}, nil
+	client := &Client{db: db, conn: conn, uri: cfg.URI, username: cfg.Username, cfg: cfg}
+	if preparedCacheEnabled.Load() {
+		client.prepared = NewPreparedCache(int(preparedCacheSize.Load()))
+	}
+	if metadataCacheEnabled.Load() {
+		client.WithMetadataCache(globalMetadataCache)
+	}
+	return client, nil
+}
+
+// buildTLSConfig assembles a *tls.Config for mTLS when the caller has
+// supplied CA/client cert material or a server-name override. It returns
+// (nil, nil) when none of those are set, since the plain grpc+tls://
+// SkipVerify path is already handled by the ADBC driver itself.
+func buildTLSConfig(cfg Config) (*tls.Config, error) {
+	if cfg.TLSCACert == "" && cfg.TLSClientCert == "" && cfg.TLSClientKey == "" && cfg.ServerNameOverride == "" {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{InsecureSkipVerify: cfg.SkipVerify}
+
+	if cfg.TLSCACert != "" {
+		pemBytes, err := os.ReadFile(cfg.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("read CA cert %q: %w", cfg.TLSCACert, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no certificates found in %q", cfg.TLSCACert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.TLSClientCert != "" || cfg.TLSClientKey != "" {
+		if cfg.TLSClientCert == "" || cfg.TLSClientKey == "" {
+			return nil, fmt.Errorf("mTLS requires both TLSClientCert and TLSClientKey")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.TLSClientCert, cfg.TLSClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load client key pair: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.ServerNameOverride != "" {
+		tlsCfg.ServerName = cfg.ServerNameOverride
+	}
+
+	return tlsCfg, nil
 }
 
+// perRPCToken implements credentials.PerRPCCredentials for bearer/OAuth2
+// auth. Unlike a header baked in at dial time, GetRequestMetadata is called
+// by gRPC before every RPC, so source (when set) is re-read each time -
+// this is what lets a refreshed OAuth2 token take effect mid-session.
+type perRPCToken struct {
+	static string
+	source OAuth2TokenSource
+	secure bool
+}
+
+func (t perRPCToken) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token := t.static
+	if t.source != nil {
+		refreshed, err := t.source(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("refresh bearer token: %w", err)
+		}
+		token = refreshed
+	}
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (t perRPCToken) RequireTransportSecurity() bool { return t.secure }
+
 // QueryResult holds the reader and statement for cleanup
 type QueryResult struct {
 	Reader array.RecordReader
 	Stmt   adbc.Statement
 }
 
-// Query executes SQL and returns Arrow RecordReader
-// Note: Caller must call result.Reader.Release() and result.Stmt.Close() when done
-func (c *Client) Query(ctx context.Context, sql string) (*QueryResult, error) {
+// wrapRPCError annotates err, the terminal error from an RPC to the Flight
+// SQL server, with op (what was being attempted) plus whatever protocol-level
+// detail is available: the gRPC status code and, if the server returned an
+// ADBC error with a SQLSTATE set, that SQLSTATE. This turns a bare ADBC error
+// string into something a DuckDB user can act on without server-side logs,
+// e.g. "[FlightSQL] permission denied (PermissionDenied; execute update;
+// SQLSTATE 42501)". Returns err unchanged if there's no extra detail to add,
+// and nil if err is nil so callers can use it unconditionally.
+func wrapRPCError(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+
+	var detail []string
+	if st, ok := status.FromError(err); ok && st.Code() != codes.OK {
+		detail = append(detail, st.Code().String())
+	}
+	if op != "" {
+		detail = append(detail, op)
+	}
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) && adbcErr.SqlState != ([5]byte{}) {
+		detail = append(detail, fmt.Sprintf("SQLSTATE %s", string(adbcErr.SqlState[:])))
+	}
+
+	if len(detail) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%s)", err, strings.Join(detail, "; "))
+}
+
+// newBoundStatement creates a statement for sql and, when params is
+// non-empty, binds it via buildParamRecord. Shared by Query/GetFlightInfo
+// and their WithParams counterparts, which differ only in which ADBC
+// execute method they call on the result.
+func (c *Client) newBoundStatement(ctx context.Context, sql string, params []any) (adbc.Statement, error) {
 	stmt, err := c.conn.NewStatement()
 	if err != nil {
 		return nil, fmt.Errorf("create statement: %w", err)
@@ -89,10 +297,59 @@ func (c *Client) Query(ctx context.Context, sql string) (*QueryResult, error) {
 		return nil, fmt.Errorf("set query: %w", err)
 	}
 
-	reader, _, err := stmt.ExecuteQuery(ctx)
+	if len(params) > 0 {
+		record, err := buildParamRecord(params)
+		if err != nil {
+			stmt.Close()
+			return nil, fmt.Errorf("build bind parameters: %w", err)
+		}
+		bindErr := stmt.Bind(ctx, record)
+		record.Release()
+		if bindErr != nil {
+			stmt.Close()
+			return nil, fmt.Errorf("bind parameters: %w", bindErr)
+		}
+	}
+
+	return stmt, nil
+}
+
+// Query executes SQL and returns Arrow RecordReader
+// Note: Caller must call result.Reader.Release() and result.Stmt.Close() when done
+func (c *Client) Query(ctx context.Context, sql string) (*QueryResult, error) {
+	return c.queryWithStatement(ctx, sql, nil)
+}
+
+// QueryWithParams is Query with positional parameter binding: sql must
+// already use the "$1", "$2", ... placeholder form (see ParseQueryParams),
+// and params supplies one value per placeholder in that order. It exists
+// alongside Query/ExecutePrepared rather than going through the prepared
+// cache because flight_query's SQL text is typically unique per call, so
+// caching it would just grow the cache without ever getting a hit.
+func (c *Client) QueryWithParams(ctx context.Context, sql string, params []any) (*QueryResult, error) {
+	return c.queryWithStatement(ctx, sql, params)
+}
+
+func (c *Client) queryWithStatement(ctx context.Context, sql string, params []any) (*QueryResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stmt, err := c.newBoundStatement(ctx, sql, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retries only cover establishing the stream: once a batch has started
+	// flowing to the caller, retrying would risk emitting rows twice.
+	var reader array.RecordReader
+	err = doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var execErr error
+		reader, _, execErr = stmt.ExecuteQuery(ctx)
+		return execErr
+	})
 	if err != nil {
 		stmt.Close()
-		return nil, fmt.Errorf("execute query: %w", err)
+		return nil, wrapRPCError(err, "execute query")
 	}
 
 	return &QueryResult{
@@ -101,10 +358,208 @@ func (c *Client) Query(ctx context.Context, sql string) (*QueryResult, error) {
 	}, nil
 }
 
+// PartitionedQuery holds the Arrow schema and one opaque partition
+// descriptor per Flight endpoint returned by ExecutePartitions, instead of
+// the single merged RecordReader Query returns. Each descriptor can be
+// opened independently via ReadPartition, which is what lets a scan fan out
+// across DuckDB worker threads - one endpoint's DoGet stream per thread -
+// rather than serializing through one connection.
+type PartitionedQuery struct {
+	Schema     *arrow.Schema
+	Partitions [][]byte
+
+	// Stmt backs the partition descriptors and must be closed once every
+	// partition's reader has been released; ReadPartition reads through the
+	// connection, not the statement, so this only needs to be kept alive to
+	// be closed, not held open for reading.
+	Stmt adbc.Statement
+}
+
+// GetFlightInfo executes sql via ADBC's ExecutePartitions, which enumerates
+// the Flight SQL server's FlightEndpoints as opaque partition descriptors
+// rather than eagerly merging them into one reader. Use ReadPartition to
+// open each descriptor independently.
+func (c *Client) GetFlightInfo(ctx context.Context, sql string) (*PartitionedQuery, error) {
+	return c.getFlightInfoWithStatement(ctx, sql, nil)
+}
+
+// GetFlightInfoWithParams is GetFlightInfo with positional parameter
+// binding, for the same reason QueryWithParams exists alongside Query: sql
+// must already be in "$1", "$2", ... form, with one value per placeholder in
+// params.
+func (c *Client) GetFlightInfoWithParams(ctx context.Context, sql string, params []any) (*PartitionedQuery, error) {
+	return c.getFlightInfoWithStatement(ctx, sql, params)
+}
+
+func (c *Client) getFlightInfoWithStatement(ctx context.Context, sql string, params []any) (*PartitionedQuery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stmt, err := c.newBoundStatement(ctx, sql, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema *arrow.Schema
+	var partitions adbc.Partitions
+	err = doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var execErr error
+		schema, partitions, _, execErr = stmt.ExecutePartitions(ctx)
+		return execErr
+	})
+	if err != nil {
+		stmt.Close()
+		return nil, wrapRPCError(err, "execute partitions")
+	}
+
+	return &PartitionedQuery{
+		Schema:     schema,
+		Partitions: partitions.PartitionIDs,
+		Stmt:       stmt,
+	}, nil
+}
+
+// ReadPartition opens a single partition descriptor (as returned in
+// PartitionedQuery.Partitions) as its own RecordReader, reading directly
+// from the Flight endpoint it names. Safe to call concurrently for distinct
+// partitions of the same GetFlightInfo call - unlike every other Client
+// method, it deliberately does not take c.mu, since it reads through its own
+// Flight DoGet stream rather than conn or a statement.
+//
+// This is the scan-phase call FetchFastest's speculative-execution scheduler
+// races: beginScan's single-endpoint fallback, duckarrow_go_scan_init_local's
+// per-thread partition reads, and QueryParallel's readPartitionsConcurrently
+// all open their partitions through this one method, so wiring the race in
+// here reaches every one of them. The first attempt reads through c's own
+// connection; only if it hasn't produced a reader within the configured
+// speculative delay does FetchFastest race it against further attempts
+// (bounded by speculative_max_attempts), each dialing its own independent
+// connection via readPartitionAlternate so a DoGet stuck on c's connection
+// can't hold up the race.
+func (c *Client) ReadPartition(ctx context.Context, partition []byte) (array.RecordReader, error) {
+	maxAttempts := int(speculativeMaxAttempts.Load())
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	attempts := make([]string, maxAttempts)
+	for i := range attempts {
+		attempts[i] = strconv.Itoa(i)
+	}
+
+	reader, err := FetchFastest(ctx, attempts, func(fctx context.Context, attempt string) (array.RecordReader, error) {
+		if attempt == "0" {
+			return c.readPartitionPrimary(fctx, partition)
+		}
+		return c.readPartitionAlternate(fctx, partition)
+	})
+	if err != nil {
+		return nil, wrapRPCError(err, "read partition")
+	}
+	return reader, nil
+}
+
+// readPartitionPrimary is attempt "0" of ReadPartition's race: the plain
+// read through c's own connection, retried the same way establishing any
+// other stream is (see queryWithStatement) - only before a batch has
+// started flowing, since retrying afterward would risk emitting rows
+// twice.
+func (c *Client) readPartitionPrimary(ctx context.Context, partition []byte) (array.RecordReader, error) {
+	var reader array.RecordReader
+	err := doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var readErr error
+		reader, readErr = c.conn.ReadPartition(ctx, partition)
+		return readErr
+	})
+	return reader, err
+}
+
+// readPartitionAlternate is every later attempt of ReadPartition's race: it
+// dials a fresh connection with c's own Config rather than reusing c.conn,
+// so the race actually exercises an independent network path instead of
+// just re-issuing the same stuck call. The dialed connection is handed off
+// to the returned reader (see ownedReader) so it stays alive for as long as
+// the reader does and is closed once the reader is - whether this attempt
+// wins the race or FetchFastest releases it as a loser.
+func (c *Client) readPartitionAlternate(ctx context.Context, partition []byte) (array.RecordReader, error) {
+	alt, err := Connect(ctx, c.cfg)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := alt.conn.ReadPartition(ctx, partition)
+	if err != nil {
+		alt.Close()
+		return nil, err
+	}
+	return &ownedReader{RecordReader: reader, owner: alt}, nil
+}
+
+// ownedReader is an array.RecordReader that also owns a *Client it must
+// close once the reader itself is released, used by readPartitionAlternate
+// to tie a speculative attempt's dialed connection to the reader it
+// produced. Every other method is promoted straight through to the embedded
+// RecordReader.
+type ownedReader struct {
+	array.RecordReader
+	owner *Client
+}
+
+func (r *ownedReader) Release() {
+	r.RecordReader.Release()
+	r.owner.Close()
+}
+
+// BeginTransaction takes the connection out of autocommit mode, opening an
+// explicit transaction that every subsequent ExecuteUpdate/
+// ExecuteUpdatePrepared call on this Client joins until Commit or Rollback
+// ends it. Backs duckarrow_begin(); the connection this is called on is
+// expected to be pinned (see PinTransaction) for the duration of the
+// transaction, since ADBC ties autocommit state to the connection, not to
+// an individual statement.
+func (c *Client) BeginTransaction(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.SetOption(adbc.OptionKeyAutoCommit, adbc.OptionValueDisabled); err != nil {
+		return wrapRPCError(err, "begin transaction")
+	}
+	return nil
+}
+
+// Commit commits the open transaction and restores autocommit mode. Backs
+// duckarrow_commit().
+func (c *Client) Commit(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.Commit(ctx); err != nil {
+		return wrapRPCError(err, "commit transaction")
+	}
+	if err := c.conn.SetOption(adbc.OptionKeyAutoCommit, adbc.OptionValueEnabled); err != nil {
+		return wrapRPCError(err, "commit transaction")
+	}
+	return nil
+}
+
+// Rollback rolls back the open transaction and restores autocommit mode.
+// Backs duckarrow_rollback() and the pool's abandoned-transaction reaper.
+func (c *Client) Rollback(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.Rollback(ctx); err != nil {
+		return wrapRPCError(err, "rollback transaction")
+	}
+	if err := c.conn.SetOption(adbc.OptionKeyAutoCommit, adbc.OptionValueEnabled); err != nil {
+		return wrapRPCError(err, "rollback transaction")
+	}
+	return nil
+}
+
 // Execute executes a non-query SQL statement (DDL/DML) and returns affected row count.
 // Use this for CREATE, DROP, INSERT, UPDATE, DELETE statements.
 // Returns -1 if the server doesn't provide affected row count.
 func (c *Client) Execute(ctx context.Context, sql string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	stmt, err := c.conn.NewStatement()
 	if err != nil {
 		return 0, fmt.Errorf("create statement: %w", err)
@@ -115,14 +570,347 @@ func (c *Client) Execute(ctx context.Context, sql string) (int64, error) {
 		return 0, fmt.Errorf("set query: %w", err)
 	}
 
+	// Not retried: ExecuteUpdate's failure codes (UNAVAILABLE,
+	// DEADLINE_EXCEEDED, RESOURCE_EXHAUSTED) are exactly the ones a client
+	// sees when the server executed the statement but the response was lost
+	// or the deadline fired mid-execution, so retrying here risks silently
+	// re-running an INSERT or UPDATE. See executeUpdatePreparedOnce, which
+	// backs duckarrow_execute and has never retried for this reason.
 	affected, err := stmt.ExecuteUpdate(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("execute update: %w", err)
+		return 0, wrapRPCError(err, "execute update")
 	}
 
 	return affected, nil
 }
 
+// ExecuteBatchError reports that ExecuteBatch stopped partway through sqls:
+// Partial holds the affected-row-count of every statement that ran
+// successfully before FailedIndex, so a caller can tell which statement
+// failed and what, if anything, already committed ahead of it.
+type ExecuteBatchError struct {
+	FailedIndex int
+	Partial     []int64
+	Err         error
+}
+
+func (e *ExecuteBatchError) Error() string {
+	return fmt.Sprintf("statement %d: %v (%d of %d statements completed)",
+		e.FailedIndex, e.Err, len(e.Partial), e.FailedIndex+1)
+}
+
+func (e *ExecuteBatchError) Unwrap() error { return e.Err }
+
+// ExecuteBatch executes sqls in order on a single ADBC statement, reusing it
+// via SetSqlQuery+ExecuteUpdate per entry rather than paying create-statement
+// overhead for each one, and returns one affected-row-count per statement.
+// Useful for schema migrations and bulk DDL/DML where duckarrow_execute's
+// per-call connection-pool round trip otherwise dominates.
+//
+// On the first failure, ExecuteBatch stops and returns an *ExecuteBatchError
+// carrying every count gathered so far, rather than just a bare error, so
+// callers can see which statement failed and what already ran. Backs
+// duckarrow_execute_batch(); sqls run in whatever transaction state the
+// connection is already in (see BeginTransaction) - ExecuteBatch itself
+// doesn't wrap them in one.
+func (c *Client) ExecuteBatch(ctx context.Context, sqls []string) ([]int64, error) {
+	if len(sqls) == 0 {
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stmt, err := c.conn.NewStatement()
+	if err != nil {
+		return nil, fmt.Errorf("create statement: %w", err)
+	}
+	defer stmt.Close()
+
+	results := make([]int64, 0, len(sqls))
+	for i, sql := range sqls {
+		if err := stmt.SetSqlQuery(sql); err != nil {
+			return results, &ExecuteBatchError{FailedIndex: i, Partial: results, Err: fmt.Errorf("set query: %w", err)}
+		}
+
+		// Not retried for the same reason as Execute: ExecuteUpdate's
+		// failure codes can't distinguish "never ran" from "ran but the
+		// response was lost", so retrying risks re-running this statement.
+		affected, err := stmt.ExecuteUpdate(ctx)
+		if err != nil {
+			return results, &ExecuteBatchError{FailedIndex: i, Partial: results, Err: wrapRPCError(err, "execute batch")}
+		}
+
+		results = append(results, affected)
+	}
+
+	return results, nil
+}
+
+// statementNotFoundMarkers are substrings of server error messages that
+// indicate a previously prepared statement is no longer known to the server
+// (e.g. because the underlying session was reset). On a match, the cache
+// entry is invalidated and the call retried once with a fresh statement.
+var statementNotFoundMarkers = []string{
+	"statement not found",
+	"prepared statement",
+	"unknown statement",
+	"invalid handle",
+}
+
+func looksLikeStatementNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range statementNotFoundMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExecutePrepared runs sql as a cached prepared statement, creating and
+// caching one on first use. On a server-side "statement not found" error the
+// cache entry is invalidated and the call is retried once with a freshly
+// prepared statement.
+//
+// Note: the returned QueryResult's Reader must be released by the caller, as
+// with Query. When the statement is cached, Stmt is nil: the cache owns the
+// prepared adbc.Statement and it must NOT be closed by the caller. With the
+// cache disabled, Stmt is the one-off statement and the caller closes it, as
+// with Query.
+func (c *Client) ExecutePrepared(ctx context.Context, sql string, params []any) (*QueryResult, error) {
+	reader, err := c.executePreparedOnce(ctx, sql, params)
+	if err != nil && looksLikeStatementNotFound(err) {
+		c.prepared.Remove(sql)
+		reader, err = c.executePreparedOnce(ctx, sql, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (c *Client) executePreparedOnce(ctx context.Context, sql string, params []any) (*QueryResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var owned adbc.Statement
+	succeeded := false
+	defer func() {
+		if owned != nil && !succeeded {
+			owned.Close()
+		}
+	}()
+
+	entry, cached := c.prepared.Get(sql)
+	if !cached {
+		stmt, err := c.conn.NewStatement()
+		if err != nil {
+			return nil, fmt.Errorf("create statement: %w", err)
+		}
+		if err := stmt.SetSqlQuery(sql); err != nil {
+			stmt.Close()
+			return nil, fmt.Errorf("set query: %w", err)
+		}
+		if err := stmt.Prepare(ctx); err != nil {
+			stmt.Close()
+			return nil, fmt.Errorf("prepare statement: %w", err)
+		}
+		entry = &preparedEntry{sql: sql, stmt: stmt}
+		if c.prepared != nil && c.prepared.capacity > 0 {
+			c.prepared.Put(entry)
+		} else {
+			// No cache to hold it (Put would close it unused), so the
+			// statement is handed back to the caller in QueryResult.Stmt.
+			owned = stmt
+		}
+	}
+
+	if len(params) > 0 {
+		record, err := buildParamRecord(params)
+		if err != nil {
+			return nil, fmt.Errorf("build bind parameters: %w", err)
+		}
+		defer record.Release()
+		if err := entry.stmt.Bind(ctx, record); err != nil {
+			return nil, fmt.Errorf("bind parameters: %w", err)
+		}
+	}
+
+	reader, _, err := entry.stmt.ExecuteQuery(ctx)
+	if err != nil {
+		return nil, wrapRPCError(err, "execute prepared query")
+	}
+
+	succeeded = true
+	return &QueryResult{Reader: reader, Stmt: owned}, nil
+}
+
+// buildParamRecord builds a single-row Arrow record from positional
+// parameters, inferring column types from the Go value of each parameter.
+// Supported types: string, the integer/float kinds, bool, and nil (null).
+func buildParamRecord(params []any) (arrow.Record, error) {
+	fields := make([]arrow.Field, len(params))
+	for i, p := range params {
+		fields[i] = arrow.Field{Name: fmt.Sprintf("$%d", i+1), Type: paramArrowType(p), Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	for i, p := range params {
+		if err := appendParam(builder.Field(i), p); err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", i+1, err)
+		}
+	}
+
+	return builder.NewRecord(), nil
+}
+
+func paramArrowType(v any) arrow.DataType {
+	switch v.(type) {
+	case nil:
+		return arrow.BinaryTypes.String
+	case string:
+		return arrow.BinaryTypes.String
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case float32, float64:
+		return arrow.PrimitiveTypes.Float64
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return arrow.PrimitiveTypes.Int64
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func appendParam(b array.Builder, v any) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch fb := b.(type) {
+	case *array.StringBuilder:
+		fb.Append(fmt.Sprintf("%v", v))
+	case *array.BooleanBuilder:
+		bv, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		fb.Append(bv)
+	case *array.Float64Builder:
+		switch n := v.(type) {
+		case float32:
+			fb.Append(float64(n))
+		case float64:
+			fb.Append(n)
+		default:
+			return fmt.Errorf("expected float, got %T", v)
+		}
+	case *array.Int64Builder:
+		n, err := toInt64(v)
+		if err != nil {
+			return err
+		}
+		fb.Append(n)
+	default:
+		return fmt.Errorf("unsupported parameter builder %T", b)
+	}
+	return nil
+}
+
+func toInt64(v any) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int8:
+		return int64(n), nil
+	case int16:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case uint:
+		return int64(n), nil
+	case uint8:
+		return int64(n), nil
+	case uint16:
+		return int64(n), nil
+	case uint32:
+		return int64(n), nil
+	case uint64:
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", v)
+	}
+}
+
+// ExecuteUpdatePrepared runs a DDL/DML statement as a cached prepared
+// statement, mirroring ExecutePrepared but for ExecuteUpdate. This is what
+// backs duckarrow_execute so repeated calls with the same SQL text (e.g. a
+// statement re-run once per input row) skip the round-trip to re-parse and
+// re-plan the statement on the server.
+func (c *Client) ExecuteUpdatePrepared(ctx context.Context, sql string, params []any) (int64, error) {
+	affected, err := c.executeUpdatePreparedOnce(ctx, sql, params)
+	if err != nil && looksLikeStatementNotFound(err) {
+		c.prepared.Remove(sql)
+		affected, err = c.executeUpdatePreparedOnce(ctx, sql, params)
+	}
+	return affected, err
+}
+
+func (c *Client) executeUpdatePreparedOnce(ctx context.Context, sql string, params []any) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, cached := c.prepared.Get(sql)
+	if !cached {
+		stmt, err := c.conn.NewStatement()
+		if err != nil {
+			return 0, fmt.Errorf("create statement: %w", err)
+		}
+		if err := stmt.SetSqlQuery(sql); err != nil {
+			stmt.Close()
+			return 0, fmt.Errorf("set query: %w", err)
+		}
+		if err := stmt.Prepare(ctx); err != nil {
+			stmt.Close()
+			return 0, fmt.Errorf("prepare statement: %w", err)
+		}
+		entry = &preparedEntry{sql: sql, stmt: stmt}
+		if c.prepared != nil && c.prepared.capacity > 0 {
+			c.prepared.Put(entry)
+		} else {
+			// No cache to hold it (Put would close it unused), so the
+			// statement is used for this one call and closed on return.
+			defer stmt.Close()
+		}
+	}
+
+	if len(params) > 0 {
+		record, err := buildParamRecord(params)
+		if err != nil {
+			return 0, fmt.Errorf("build bind parameters: %w", err)
+		}
+		defer record.Release()
+		if err := entry.stmt.Bind(ctx, record); err != nil {
+			return 0, fmt.Errorf("bind parameters: %w", err)
+		}
+	}
+
+	affected, err := entry.stmt.ExecuteUpdate(ctx)
+	if err != nil {
+		return 0, wrapRPCError(err, "execute prepared update")
+	}
+	return affected, nil
+}
+
 // IsHealthy checks if the connection is still valid
 func (c *Client) IsHealthy() bool {
 	return c.conn != nil && c.db != nil
@@ -130,21 +918,48 @@ func (c *Client) IsHealthy() bool {
 
 // GetSchemas returns a list of schema names from the Flight SQL server.
 // It first tries ADBC GetObjects; if that fails, it falls back to SQL query.
+// Results are memoized in c.metadataCache (see duckarrow_invalidate_cache to
+// force a refresh).
 func (c *Client) GetSchemas(ctx context.Context) ([]string, error) {
-	schemas, err := c.getSchemasViaADBC(ctx)
-	if err == nil {
-		return schemas, nil
+	key := c.metadataCacheKey("schemas", "", "")
+	if cached, ok := c.metadataCache.Get(key); ok {
+		return cached.([]string), nil
+	}
+
+	schemas, err := c.getSchemasUncached(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fall back to SQL query
-	return c.getSchemasViaSQL(ctx)
+	c.metadataCache.Put(key, schemas)
+	return schemas, nil
+}
+
+// getSchemasUncached is GetSchemas' actual RPC, split out so callers that
+// need to prove the server is actually reachable - notably Pool.probe - can
+// issue it without a cache hit short-circuiting the round trip.
+func (c *Client) getSchemasUncached(ctx context.Context) ([]string, error) {
+	schemas, err := c.getSchemasViaADBC(ctx)
+	if err != nil {
+		// Fall back to SQL query
+		schemas, err = c.getSchemasViaSQL(ctx)
+	}
+	return schemas, err
 }
 
 // getSchemasViaADBC uses ADBC GetObjects to retrieve schema names
 func (c *Client) getSchemasViaADBC(ctx context.Context) ([]string, error) {
-	reader, err := c.conn.GetObjects(ctx, adbc.ObjectDepthDBSchemas, nil, nil, nil, nil, nil)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reader array.RecordReader
+	err := doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var getErr error
+		reader, getErr = c.conn.GetObjects(ctx, adbc.ObjectDepthDBSchemas, nil, nil, nil, nil, nil)
+		return getErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get objects: %w", err)
+		return nil, wrapRPCError(err, "get objects")
 	}
 	defer reader.Release()
 
@@ -249,21 +1064,40 @@ type TableInfo struct {
 
 // GetTables returns a list of tables from the Flight SQL server for a given schema.
 // It first tries ADBC GetObjects; if that fails, it falls back to SQL query.
+// Results are memoized in c.metadataCache (see duckarrow_invalidate_cache to
+// force a refresh).
 func (c *Client) GetTables(ctx context.Context, schema string) ([]TableInfo, error) {
+	key := c.metadataCacheKey("tables", schema, "")
+	if cached, ok := c.metadataCache.Get(key); ok {
+		return cached.([]TableInfo), nil
+	}
+
 	tables, err := c.getTablesViaADBC(ctx, schema)
-	if err == nil {
-		return tables, nil
+	if err != nil {
+		// Fall back to SQL query
+		tables, err = c.getTablesViaSQL(ctx, schema)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// Fall back to SQL query
-	return c.getTablesViaSQL(ctx, schema)
+	c.metadataCache.Put(key, tables)
+	return tables, nil
 }
 
 // getTablesViaADBC uses ADBC GetObjects to retrieve table names for a schema
 func (c *Client) getTablesViaADBC(ctx context.Context, schema string) ([]TableInfo, error) {
-	reader, err := c.conn.GetObjects(ctx, adbc.ObjectDepthTables, nil, &schema, nil, nil, nil)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reader array.RecordReader
+	err := doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var getErr error
+		reader, getErr = c.conn.GetObjects(ctx, adbc.ObjectDepthTables, nil, &schema, nil, nil, nil)
+		return getErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get objects: %w", err)
+		return nil, wrapRPCError(err, "get objects")
 	}
 	defer reader.Release()
 
@@ -359,10 +1193,13 @@ func (c *Client) getTablesViaADBC(ctx context.Context, schema string) ([]TableIn
 	return tables, nil
 }
 
-// getTablesViaSQL uses a SQL query to retrieve table names for a schema
+// getTablesViaSQL uses a SQL query to retrieve table names for a schema.
+// schema is bound as a query parameter rather than interpolated into the SQL
+// text, since it may come from an untrusted caller (e.g. a schema name
+// forwarded from DuckDB's information_schema introspection).
 func (c *Client) getTablesViaSQL(ctx context.Context, schema string) ([]TableInfo, error) {
-	query := fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = '%s'", schema)
-	result, err := c.Query(ctx, query)
+	query := "SELECT table_name FROM information_schema.tables WHERE table_schema = $1"
+	result, err := c.QueryWithParams(ctx, query, []any{schema})
 	if err != nil {
 		return nil, fmt.Errorf("query tables: %w", err)
 	}
@@ -410,21 +1247,40 @@ type ColumnInfo struct {
 
 // GetColumns returns column information for a table.
 // It first tries ADBC GetObjects; if that fails, it falls back to SQL query.
+// Results are memoized in c.metadataCache (see duckarrow_invalidate_cache to
+// force a refresh).
 func (c *Client) GetColumns(ctx context.Context, schema, table string) ([]ColumnInfo, error) {
+	key := c.metadataCacheKey("columns", schema, table)
+	if cached, ok := c.metadataCache.Get(key); ok {
+		return cached.([]ColumnInfo), nil
+	}
+
 	columns, err := c.getColumnsViaADBC(ctx, schema, table)
-	if err == nil {
-		return columns, nil
+	if err != nil {
+		// Fall back to SQL query
+		columns, err = c.getColumnsViaSQL(ctx, schema, table)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// Fall back to SQL query
-	return c.getColumnsViaSQL(ctx, schema, table)
+	c.metadataCache.Put(key, columns)
+	return columns, nil
 }
 
 // getColumnsViaADBC uses ADBC GetObjects to retrieve column information
 func (c *Client) getColumnsViaADBC(ctx context.Context, schema, table string) ([]ColumnInfo, error) {
-	reader, err := c.conn.GetObjects(ctx, adbc.ObjectDepthColumns, nil, &schema, nil, &table, nil)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reader array.RecordReader
+	err := doWithRetry(ctx, currentRetryPolicy(), func() error {
+		var getErr error
+		reader, getErr = c.conn.GetObjects(ctx, adbc.ObjectDepthColumns, nil, &schema, nil, &table, nil)
+		return getErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get objects: %w", err)
+		return nil, wrapRPCError(err, "get objects")
 	}
 	defer reader.Release()
 
@@ -564,13 +1420,13 @@ func (c *Client) getColumnsViaADBC(ctx context.Context, schema, table string) ([
 	return columns, nil
 }
 
-// getColumnsViaSQL uses a SQL query to retrieve column information
+// getColumnsViaSQL uses a SQL query to retrieve column information. schema
+// and table are bound as query parameters rather than interpolated into the
+// SQL text, for the same reason as getTablesViaSQL.
 func (c *Client) getColumnsViaSQL(ctx context.Context, schema, table string) ([]ColumnInfo, error) {
-	query := fmt.Sprintf(
-		"SELECT column_name, data_type, is_nullable, ordinal_position FROM information_schema.columns WHERE table_schema = '%s' AND table_name = '%s' ORDER BY ordinal_position",
-		schema, table,
-	)
-	result, err := c.Query(ctx, query)
+	query := "SELECT column_name, data_type, is_nullable, ordinal_position FROM information_schema.columns " +
+		"WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position"
+	result, err := c.QueryWithParams(ctx, query, []any{schema, table})
 	if err != nil {
 		return nil, fmt.Errorf("query columns: %w", err)
 	}
@@ -642,6 +1498,8 @@ func (c *Client) getColumnsViaSQL(ctx context.Context, schema, table string) ([]
 
 // Close closes connection and database
 func (c *Client) Close() error {
+	c.prepared.Close()
+
 	var errs []error
 	if c.conn != nil {
 		if err := c.conn.Close(); err != nil {
@@ -0,0 +1,182 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuildTLSConfigNoneSet(t *testing.T) {
+	cfg, err := buildTLSConfig(Config{URI: "grpc://localhost:31337"})
+	if err != nil {
+		t.Fatalf("buildTLSConfig() error = %v", err)
+	}
+	if cfg != nil {
+		t.Errorf("buildTLSConfig() = %+v, want nil when no TLS fields are set", cfg)
+	}
+}
+
+func TestBuildTLSConfigServerNameOverrideOnly(t *testing.T) {
+	cfg, err := buildTLSConfig(Config{ServerNameOverride: "override.example.com"})
+	if err != nil {
+		t.Fatalf("buildTLSConfig() error = %v", err)
+	}
+	if cfg == nil || cfg.ServerName != "override.example.com" {
+		t.Errorf("buildTLSConfig() = %+v, want ServerName override.example.com", cfg)
+	}
+}
+
+func TestBuildTLSConfigMissingCAFile(t *testing.T) {
+	_, err := buildTLSConfig(Config{TLSCACert: filepath.Join(t.TempDir(), "does-not-exist.pem")})
+	if err == nil {
+		t.Fatal("expected error for missing CA cert file")
+	}
+}
+
+func TestBuildTLSConfigClientCertRequiresBoth(t *testing.T) {
+	_, err := buildTLSConfig(Config{TLSClientCert: "only-cert.pem"})
+	if err == nil {
+		t.Fatal("expected error when TLSClientKey is missing")
+	}
+}
+
+func TestBuildTLSConfigInvalidCAPem(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.pem")
+	if err := os.WriteFile(path, []byte("not a real cert"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	_, err := buildTLSConfig(Config{TLSCACert: path})
+	if err == nil {
+		t.Fatal("expected error for unparsable CA cert")
+	}
+}
+
+func TestPerRPCTokenStaticToken(t *testing.T) {
+	tok := perRPCToken{static: "abc123"}
+	md, err := tok.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() error = %v", err)
+	}
+	if md["authorization"] != "Bearer abc123" {
+		t.Errorf("authorization = %q, want %q", md["authorization"], "Bearer abc123")
+	}
+}
+
+func TestPerRPCTokenSourceTakesPrecedenceAndRefreshes(t *testing.T) {
+	calls := 0
+	tok := perRPCToken{
+		static: "stale",
+		source: func(ctx context.Context) (string, error) {
+			calls++
+			return "fresh-token", nil
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		md, err := tok.GetRequestMetadata(context.Background())
+		if err != nil {
+			t.Fatalf("GetRequestMetadata() error = %v", err)
+		}
+		if md["authorization"] != "Bearer fresh-token" {
+			t.Errorf("authorization = %q, want %q", md["authorization"], "Bearer fresh-token")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("token source called %d times, want 2 (re-read per call)", calls)
+	}
+}
+
+func TestPerRPCTokenSourceError(t *testing.T) {
+	wantErr := errors.New("refresh failed")
+	tok := perRPCToken{source: func(ctx context.Context) (string, error) { return "", wantErr }}
+
+	if _, err := tok.GetRequestMetadata(context.Background()); err == nil {
+		t.Fatal("expected error to propagate from token source")
+	}
+}
+
+func TestPerRPCTokenNoneSetReturnsNilMetadata(t *testing.T) {
+	tok := perRPCToken{}
+	md, err := tok.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() error = %v", err)
+	}
+	if md != nil {
+		t.Errorf("metadata = %v, want nil when no token is configured", md)
+	}
+}
+
+func TestPerRPCTokenRequireTransportSecurity(t *testing.T) {
+	if (perRPCToken{secure: true}).RequireTransportSecurity() != true {
+		t.Error("RequireTransportSecurity() = false, want true")
+	}
+	if (perRPCToken{secure: false}).RequireTransportSecurity() != false {
+		t.Error("RequireTransportSecurity() = true, want false")
+	}
+}
+
+func TestWrapRPCErrorNil(t *testing.T) {
+	if err := wrapRPCError(nil, "execute query"); err != nil {
+		t.Errorf("wrapRPCError(nil, ...) = %v, want nil", err)
+	}
+}
+
+func TestWrapRPCErrorPlainErrorAddsOp(t *testing.T) {
+	err := wrapRPCError(errors.New("boom"), "execute query")
+	if err == nil {
+		t.Fatal("wrapRPCError() = nil, want error")
+	}
+	want := "boom (execute query)"
+	if err.Error() != want {
+		t.Errorf("wrapRPCError() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestWrapRPCErrorGRPCStatusAddsCode(t *testing.T) {
+	base := status.Error(codes.PermissionDenied, "permission denied")
+	err := wrapRPCError(base, "execute update")
+	want := "rpc error: code = PermissionDenied desc = permission denied (PermissionDenied; execute update)"
+	if err.Error() != want {
+		t.Errorf("wrapRPCError() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestWrapRPCErrorAdbcSqlStateAppended(t *testing.T) {
+	base := adbc.Error{Msg: "[FlightSQL] permission denied", SqlState: [5]byte{'4', '2', '5', '0', '1'}}
+	err := wrapRPCError(base, "execute update")
+	if !strings.Contains(err.Error(), "execute update; SQLSTATE 42501") {
+		t.Errorf("wrapRPCError() = %q, want it to mention the op and SQLSTATE 42501", err.Error())
+	}
+}
+
+func TestExecuteBatchErrorMessageReportsProgress(t *testing.T) {
+	err := &ExecuteBatchError{FailedIndex: 2, Partial: []int64{1, 1}, Err: errors.New("boom")}
+	want := "statement 2: boom (2 of 3 statements completed)"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestExecuteBatchErrorUnwrap(t *testing.T) {
+	inner := errors.New("boom")
+	err := &ExecuteBatchError{FailedIndex: 0, Err: inner}
+	if !errors.Is(err, inner) {
+		t.Error("expected errors.Is to find the wrapped error via Unwrap")
+	}
+}
+
+func TestWrapRPCErrorNoDetailReturnsOriginal(t *testing.T) {
+	base := errors.New("boom")
+	err := wrapRPCError(base, "")
+	if !errors.Is(err, base) || err.Error() != base.Error() {
+		t.Errorf("wrapRPCError(err, \"\") = %v, want err returned unchanged", err)
+	}
+}
@@ -0,0 +1,178 @@
+package flight
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultMetadataCacheCapacity and defaultMetadataCacheTTL are the
+// out-of-the-box LRU parameters for the process-wide catalog metadata cache.
+const (
+	defaultMetadataCacheCapacity = 1024
+	defaultMetadataCacheTTL      = 30 * time.Second
+)
+
+// metadataCacheEnabled is a process-wide default, tunable at runtime via
+// duckarrow_configure_option('metadata_cache_enabled', ...). New clients
+// pick up the current value at Connect() time.
+var metadataCacheEnabled atomic.Bool
+
+func init() {
+	metadataCacheEnabled.Store(true)
+}
+
+// SetMetadataCacheEnabled toggles whether clients created after this call
+// memoize GetSchemas/GetTables/GetColumns through globalMetadataCache.
+func SetMetadataCacheEnabled(enabled bool) {
+	metadataCacheEnabled.Store(enabled)
+}
+
+// globalMetadataCache is shared across every Client, the same way globalPool
+// is shared across pooled connections: two Clients talking to the same
+// Flight SQL server (e.g. one pooled channel serving several ATTACHes)
+// should see each other's cached catalog lookups rather than each keeping a
+// cold, private cache. Keying by (URI, username, ...) keeps entries from
+// distinct servers/credentials from colliding.
+var globalMetadataCache = NewLRUCache(defaultMetadataCacheCapacity, defaultMetadataCacheTTL)
+
+// InvalidateMetadataCache clears every cached catalog lookup, forcing the
+// next GetSchemas/GetTables/GetColumns call on any client to hit the Flight
+// SQL server. Backs duckarrow_invalidate_cache().
+func InvalidateMetadataCache() {
+	globalMetadataCache.InvalidateAll()
+}
+
+// metadataCacheEntry is one cached GetSchemas/GetTables/GetColumns result.
+type metadataCacheEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// LRUCache is a bounded, TTL-expiring cache of catalog metadata
+// (GetSchemas/GetTables/GetColumns results), modeled on PreparedCache: a
+// map + doubly-linked list guarded by one mutex, with LRU eviction on
+// capacity overflow. Unlike PreparedCache it also expires entries after ttl,
+// since a stale catalog lookup silently returning a dropped table or column
+// is a worse failure mode than a stale prepared statement (which errors out
+// loudly via looksLikeStatementNotFound).
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // front = most recently used
+}
+
+// NewLRUCache creates a metadata cache holding at most capacity entries,
+// each valid for ttl after it was written. A non-positive capacity disables
+// caching.
+func NewLRUCache(capacity int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached value for key, promoting it to most-recently-used.
+// An expired entry is evicted and reported as a miss.
+func (c *LRUCache) Get(key string) (any, bool) {
+	if c == nil || c.capacity <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*metadataCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.evictElement(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Put inserts or replaces the cached value for key, evicting the
+// least-recently-used entry if the cache is at capacity.
+func (c *LRUCache) Put(key string, value any) {
+	if c == nil || c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*metadataCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&metadataCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	c.entries[key] = elem
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.evictElement(oldest)
+	}
+}
+
+// Invalidate removes the cached entry for key, if any.
+func (c *LRUCache) Invalidate(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.evictElement(elem)
+	}
+}
+
+// InvalidateAll clears every cached entry.
+func (c *LRUCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// evictElement removes elem from both the map and the list. Callers must
+// hold c.mu.
+func (c *LRUCache) evictElement(elem *list.Element) {
+	entry := elem.Value.(*metadataCacheEntry)
+	delete(c.entries, entry.key)
+	c.order.Remove(elem)
+}
+
+// metadataCacheKey builds the cache key for a GetSchemas/GetTables/
+// GetColumns call: (URI, username, objectKind, schema, table). schema and
+// table are left empty when the call they're keying doesn't scope by them
+// (e.g. GetSchemas has no schema/table). \x1f (unit separator) joins the
+// parts since it can't appear in a URI, username, schema, or table name.
+func (c *Client) metadataCacheKey(objectKind, schema, table string) string {
+	return strings.Join([]string{c.uri, c.username, objectKind, schema, table}, "\x1f")
+}
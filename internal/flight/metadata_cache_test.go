@@ -0,0 +1,134 @@
+package flight
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacheGetMiss(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+	if _, ok := c.Get("schemas"); ok {
+		t.Error("expected miss on empty cache")
+	}
+}
+
+func TestLRUCachePutAndGet(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+	c.Put("schemas", []string{"public"})
+
+	got, ok := c.Get("schemas")
+	if !ok {
+		t.Fatal("expected hit after Put")
+	}
+	if schemas := got.([]string); len(schemas) != 1 || schemas[0] != "public" {
+		t.Errorf("Get() = %v, want [public]", schemas)
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+
+	c.Put("A", 1)
+	c.Put("B", 2)
+
+	// Touch A so B becomes the least-recently-used entry.
+	if _, ok := c.Get("A"); !ok {
+		t.Fatal("expected A to be cached")
+	}
+
+	c.Put("C", 3)
+
+	if _, ok := c.Get("B"); ok {
+		t.Error("expected B to be evicted as least-recently-used")
+	}
+	if _, ok := c.Get("A"); !ok {
+		t.Error("expected A to remain cached")
+	}
+	if _, ok := c.Get("C"); !ok {
+		t.Error("expected C to be cached")
+	}
+}
+
+func TestLRUCacheZeroCapacityDisabled(t *testing.T) {
+	c := NewLRUCache(0, time.Minute)
+	c.Put("schemas", []string{"public"})
+
+	if _, ok := c.Get("schemas"); ok {
+		t.Error("zero-capacity cache should never hit")
+	}
+}
+
+func TestLRUCacheExpiresAfterTTL(t *testing.T) {
+	c := NewLRUCache(2, time.Nanosecond)
+	c.Put("schemas", []string{"public"})
+	time.Sleep(time.Millisecond)
+
+	if _, ok := c.Get("schemas"); ok {
+		t.Error("expected entry to have expired")
+	}
+}
+
+func TestLRUCacheReplaceExistingKeyRefreshesTTL(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+	c.Put("schemas", []string{"public"})
+	c.Put("schemas", []string{"public", "analytics"})
+
+	got, ok := c.Get("schemas")
+	if !ok {
+		t.Fatal("expected hit after replace")
+	}
+	if schemas := got.([]string); len(schemas) != 2 {
+		t.Errorf("Get() = %v, want replaced value with 2 schemas", schemas)
+	}
+}
+
+func TestLRUCacheInvalidate(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+	c.Put("schemas", []string{"public"})
+
+	c.Invalidate("schemas")
+
+	if _, ok := c.Get("schemas"); ok {
+		t.Error("expected invalidated entry to miss")
+	}
+}
+
+func TestLRUCacheInvalidateAll(t *testing.T) {
+	c := NewLRUCache(2, time.Minute)
+	c.Put("A", 1)
+	c.Put("B", 2)
+
+	c.InvalidateAll()
+
+	if _, ok := c.Get("A"); ok {
+		t.Error("expected InvalidateAll to empty the cache")
+	}
+	if _, ok := c.Get("B"); ok {
+		t.Error("expected InvalidateAll to empty the cache")
+	}
+}
+
+func TestNilLRUCacheIsSafe(t *testing.T) {
+	var c *LRUCache
+	if _, ok := c.Get("x"); ok {
+		t.Error("nil cache should never hit")
+	}
+	c.Put("x", 1) // must not panic
+	c.Invalidate("x")
+	c.InvalidateAll()
+}
+
+func TestClientMetadataCacheKeyDistinguishesObjectKindAndScope(t *testing.T) {
+	a := &Client{uri: "grpc://host:1", username: "alice"}
+	b := &Client{uri: "grpc://host:2", username: "alice"}
+
+	if a.metadataCacheKey("schemas", "", "") == b.metadataCacheKey("schemas", "", "") {
+		t.Error("expected keys to differ across distinct URIs")
+	}
+	if a.metadataCacheKey("tables", "public", "") == a.metadataCacheKey("columns", "public", "") {
+		t.Error("expected keys to differ across object kinds")
+	}
+	if a.metadataCacheKey("columns", "public", "t1") == a.metadataCacheKey("columns", "public", "t2") {
+		t.Error("expected keys to differ across tables")
+	}
+}
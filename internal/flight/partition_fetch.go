@@ -0,0 +1,207 @@
+package flight
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// defaultSpeculativeDelay is how long the scheduler waits for the first
+// endpoint to produce a batch before racing an alternate location, when the
+// delay hasn't been explicitly configured.
+const defaultSpeculativeDelay = 100 * time.Millisecond
+
+// defaultSpeculativeMaxAttempts bounds how many attempts in total, the
+// original request included, the scheduler will race for the same ticket.
+const defaultSpeculativeMaxAttempts = 2
+
+// defaultMaxParallelEndpoints bounds how many *distinct* endpoints (true
+// partitioned scans, as opposed to redundant copies of the same ticket) are
+// fetched concurrently.
+const defaultMaxParallelEndpoints = 4
+
+var (
+	speculativeDelayMs      atomic.Int64
+	speculativeMaxAttempts  atomic.Int64
+	maxParallelEndpointsVal atomic.Int64
+)
+
+func init() {
+	speculativeDelayMs.Store(defaultSpeculativeDelay.Milliseconds())
+	speculativeMaxAttempts.Store(defaultSpeculativeMaxAttempts)
+	maxParallelEndpointsVal.Store(defaultMaxParallelEndpoints)
+}
+
+// SetSpeculativeDelay changes how long the scheduler waits for the first
+// endpoint before racing an alternate location for the same ticket.
+func SetSpeculativeDelay(d time.Duration) {
+	speculativeDelayMs.Store(d.Milliseconds())
+}
+
+// SetSpeculativeMaxAttempts changes how many attempts in total, the original
+// request included, may be raced for a single ticket.
+func SetSpeculativeMaxAttempts(n int) {
+	speculativeMaxAttempts.Store(int64(n))
+}
+
+// SetMaxParallelEndpoints changes how many distinct endpoints are fetched
+// concurrently for a true partitioned scan.
+func SetMaxParallelEndpoints(n int) {
+	maxParallelEndpointsVal.Store(int64(n))
+}
+
+// EndpointFetcher opens a DoGet-style stream against a single Flight
+// endpoint location, honoring ctx cancellation.
+type EndpointFetcher func(ctx context.Context, location string) (array.RecordReader, error)
+
+// endpointResult is the outcome of racing a single location.
+type endpointResult struct {
+	reader array.RecordReader
+	err    error
+}
+
+// FetchFastest races DoGet calls against locations that all serve the same
+// ticket (redundant copies of one partition), modeled on gocql's speculative
+// retry: the first location is tried immediately; if it hasn't produced a
+// reader within the configured delay, a second location is raced against it,
+// and so on up to the configured max attempts. Whichever location answers
+// first wins; the others are cancelled via ctx.
+//
+// Client.ReadPartition is what wires this into the scan phase: its first
+// attempt is a plain read through the caller's own connection, and any
+// later attempt FetchFastest races in dials an independent connection (see
+// readPartitionAlternate) rather than re-issuing the same call, so a single
+// stuck DoGet stream can't hold up every reader of that partition.
+//
+// If locations has only one entry, this degenerates to a plain fetch.
+func FetchFastest(ctx context.Context, locations []string, fetch EndpointFetcher) (array.RecordReader, error) {
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("no locations provided")
+	}
+	if len(locations) == 1 {
+		return fetch(ctx, locations[0])
+	}
+
+	delay := time.Duration(speculativeDelayMs.Load()) * time.Millisecond
+	maxAttempts := int(speculativeMaxAttempts.Load())
+	if maxAttempts > len(locations) {
+		maxAttempts = len(locations)
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	// raceCtx is cancelled once a winner is found (or all attempts fail) so
+	// any losing in-flight DoGet calls unwind promptly. The winner's reader
+	// is unaffected: it's a value already returned by fetch, not tied to
+	// raceCtx staying uncancelled.
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan endpointResult, maxAttempts)
+	started := 0
+
+	launch := func(location string) {
+		started++
+		go func(loc string) {
+			reader, err := fetch(raceCtx, loc)
+			select {
+			case results <- endpointResult{reader: reader, err: err}:
+			case <-raceCtx.Done():
+				if reader != nil {
+					reader.Release()
+				}
+			}
+		}(location)
+	}
+
+	launch(locations[0])
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var firstErr error
+	received := 0
+
+	for {
+		select {
+		case res := <-results:
+			received++
+			if res.err == nil {
+				cancel() // stop any in-flight losers; winner's reader survives
+				return res.reader, nil
+			}
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			if received == started && started >= maxAttempts {
+				cancel()
+				return nil, fmt.Errorf("all %d endpoint attempts failed, first error: %w", started, firstErr)
+			}
+		case <-timer.C:
+			if started < maxAttempts {
+				launch(locations[started])
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			cancel()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// PartitionFetch fetches distinct endpoints (a true partitioned scan, not
+// redundant copies of one ticket) concurrently, bounded by the current
+// max_parallel_endpoints setting, and returns one reader per location in the
+// same order as locations. A caller merges them (e.g. round-robin or
+// concatenation) to present a single logical stream.
+func PartitionFetch(ctx context.Context, locations []string, fetch EndpointFetcher) ([]array.RecordReader, error) {
+	limit := int(maxParallelEndpointsVal.Load())
+	return partitionFetch(ctx, locations, limit, fetch)
+}
+
+// partitionFetch is PartitionFetch with an explicit concurrency bound,
+// rather than the current max_parallel_endpoints setting. It backs both
+// PartitionFetch and Client.readPartitionsConcurrently, which is what
+// QueryParallel uses to fan out a flight_query's endpoints with whatever
+// concurrency the caller requested (falling back to max_parallel_endpoints
+// itself when the caller didn't specify one).
+func partitionFetch(ctx context.Context, locations []string, limit int, fetch EndpointFetcher) ([]array.RecordReader, error) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	readers := make([]array.RecordReader, len(locations))
+	errs := make([]error, len(locations))
+	sem := make(chan struct{}, limit)
+	done := make(chan int, len(locations))
+
+	for i, loc := range locations {
+		sem <- struct{}{}
+		go func(idx int, location string) {
+			defer func() { <-sem }()
+			readers[idx], errs[idx] = fetch(ctx, location)
+			done <- idx
+		}(i, loc)
+	}
+
+	for range locations {
+		<-done
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			for _, r := range readers {
+				if r != nil {
+					r.Release()
+				}
+			}
+			return nil, fmt.Errorf("endpoint %d: %s: %w", i, locations[i], err)
+		}
+	}
+
+	return readers, nil
+}
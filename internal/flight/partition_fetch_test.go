@@ -0,0 +1,171 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+func TestFetchFastestSingleLocation(t *testing.T) {
+	called := 0
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		called++
+		if location != "only" {
+			t.Errorf("unexpected location %q", location)
+		}
+		return nil, nil
+	}
+
+	if _, err := FetchFastest(context.Background(), []string{"only"}, fetch); err != nil {
+		t.Fatalf("FetchFastest() error = %v", err)
+	}
+	if called != 1 {
+		t.Errorf("fetch called %d times, want 1", called)
+	}
+}
+
+func TestFetchFastestFirstWinsImmediately(t *testing.T) {
+	SetSpeculativeDelay(50 * time.Millisecond)
+	SetSpeculativeMaxAttempts(2)
+	defer func() {
+		SetSpeculativeDelay(defaultSpeculativeDelay)
+		SetSpeculativeMaxAttempts(defaultSpeculativeMaxAttempts)
+	}()
+
+	secondCalled := make(chan struct{}, 1)
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		if location == "second" {
+			secondCalled <- struct{}{}
+			return nil, nil
+		}
+		return nil, nil // "first" answers instantly
+	}
+
+	_, err := FetchFastest(context.Background(), []string{"first", "second"}, fetch)
+	if err != nil {
+		t.Fatalf("FetchFastest() error = %v", err)
+	}
+
+	select {
+	case <-secondCalled:
+		t.Error("expected second location not to be raced when first answers before the delay")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFetchFastestRacesSecondAfterDelay(t *testing.T) {
+	SetSpeculativeDelay(10 * time.Millisecond)
+	SetSpeculativeMaxAttempts(2)
+	defer func() {
+		SetSpeculativeDelay(defaultSpeculativeDelay)
+		SetSpeculativeMaxAttempts(defaultSpeculativeMaxAttempts)
+	}()
+
+	block := make(chan struct{})
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		if location == "slow" {
+			<-block
+			return nil, nil
+		}
+		return nil, nil // "fast" answers after the race starts, but before "slow" ever does
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := FetchFastest(context.Background(), []string{"slow", "fast"}, fetch)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("FetchFastest() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FetchFastest did not race the second location after the delay")
+	}
+	close(block)
+}
+
+func TestFetchFastestAllFail(t *testing.T) {
+	SetSpeculativeDelay(5 * time.Millisecond)
+	SetSpeculativeMaxAttempts(2)
+	defer func() {
+		SetSpeculativeDelay(defaultSpeculativeDelay)
+		SetSpeculativeMaxAttempts(defaultSpeculativeMaxAttempts)
+	}()
+
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		return nil, errors.New("boom: " + location)
+	}
+
+	_, err := FetchFastest(context.Background(), []string{"a", "b"}, fetch)
+	if err == nil {
+		t.Fatal("expected error when all endpoints fail")
+	}
+}
+
+func TestFetchFastestNoLocations(t *testing.T) {
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		return nil, nil
+	}
+	if _, err := FetchFastest(context.Background(), nil, fetch); err == nil {
+		t.Error("expected error with no locations")
+	}
+}
+
+func TestPartitionFetchReturnsOneReaderPerLocation(t *testing.T) {
+	SetMaxParallelEndpoints(4)
+	defer SetMaxParallelEndpoints(defaultMaxParallelEndpoints)
+
+	locations := []string{"a", "b", "c"}
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		return nil, nil
+	}
+
+	readers, err := PartitionFetch(context.Background(), locations, fetch)
+	if err != nil {
+		t.Fatalf("PartitionFetch() error = %v", err)
+	}
+	if len(readers) != len(locations) {
+		t.Errorf("got %d readers, want %d", len(readers), len(locations))
+	}
+}
+
+func TestPartitionFetchPropagatesError(t *testing.T) {
+	fetch := func(ctx context.Context, location string) (array.RecordReader, error) {
+		if location == "bad" {
+			return nil, errors.New("endpoint failure")
+		}
+		return nil, nil
+	}
+
+	_, err := PartitionFetch(context.Background(), []string{"good", "bad"}, fetch)
+	if err == nil {
+		t.Error("expected error when one endpoint fails")
+	}
+}
+
+func TestSetSpeculativeConfigRoundTrip(t *testing.T) {
+	SetSpeculativeDelay(250 * time.Millisecond)
+	SetSpeculativeMaxAttempts(3)
+	SetMaxParallelEndpoints(8)
+	defer func() {
+		SetSpeculativeDelay(defaultSpeculativeDelay)
+		SetSpeculativeMaxAttempts(defaultSpeculativeMaxAttempts)
+		SetMaxParallelEndpoints(defaultMaxParallelEndpoints)
+	}()
+
+	if got := speculativeDelayMs.Load(); got != 250 {
+		t.Errorf("speculativeDelayMs = %d, want 250", got)
+	}
+	if got := speculativeMaxAttempts.Load(); got != 3 {
+		t.Errorf("speculativeMaxAttempts = %d, want 3", got)
+	}
+	if got := maxParallelEndpointsVal.Load(); got != 8 {
+		t.Errorf("maxParallelEndpointsVal = %d, want 8", got)
+	}
+}
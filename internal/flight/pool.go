@@ -5,53 +5,377 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// PooledClient wraps a Client with pool metadata
+// defaultHealthCheckInterval is how often the background reaper probes idle
+// pooled connections when the interval hasn't been explicitly configured.
+const defaultHealthCheckInterval = 30 * time.Second
+
+// defaultHealthCheckTimeout bounds how long a single liveness probe may take.
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// defaultMaxStreamsPerConn is how many concurrent in-flight RPCs a single
+// pooled gRPC channel is allowed to carry before Get opens another one. Each
+// channel is one *Client, and Client.mu serializes every ADBC call on it end
+// to end (ADBC connections aren't safe for concurrent use), so packing
+// callers onto the same channel only ever queues them behind that mutex
+// rather than running them concurrently. The default is 1 so Get's
+// least-loaded scan dials a fresh channel - a real, independent gRPC
+// connection - for each additional concurrent caller instead of papering
+// over the serialization; raise it only if you've confirmed your workload
+// doesn't need per-caller concurrency and want to trade that for fewer
+// open connections.
+const defaultMaxStreamsPerConn = 1
+
+// probing is the sentinel inFlight value a channel is parked at while the
+// health-check loop has it reserved for a liveness probe. Get treats any
+// negative count as saturated so it never hands out a channel mid-probe.
+const probing = -1
+
+// pooledChannel is one gRPC-backed Client within a PooledClient's fan-out
+// set, plus the bookkeeping Pool needs to load-balance and reap it.
+type pooledChannel struct {
+	client     *Client
+	inFlight   atomic.Int32 // concurrent leases on this channel; probing while < 0
+	pinned     atomic.Bool  // exclusively reserved for a pinned transaction; excluded from Get's scan entirely
+	lastActive atomic.Int64 // unix nano, touched on acquire/release
+	createdAt  int64        // unix nano, set once at dial time
+}
+
+func newPooledChannel(client *Client) *pooledChannel {
+	ch := &pooledChannel{client: client, createdAt: time.Now().UnixNano()}
+	ch.touch()
+	return ch
+}
+
+func (ch *pooledChannel) age() time.Duration {
+	return time.Since(time.Unix(0, ch.createdAt))
+}
+
+func (ch *pooledChannel) touch() {
+	ch.lastActive.Store(time.Now().UnixNano())
+}
+
+func (ch *pooledChannel) idleFor() time.Duration {
+	return time.Since(time.Unix(0, ch.lastActive.Load()))
+}
+
+// tryReserveForProbe claims the channel for a health-check probe, but only
+// when it's currently idle - an in-flight channel is left alone so a live
+// query is never disrupted.
+func (ch *pooledChannel) tryReserveForProbe() bool {
+	return ch.inFlight.CompareAndSwap(0, probing)
+}
+
+// PooledClient fans a single (uri, credentials) config out across a small
+// set of underlying gRPC channels, so concurrent queries against the same
+// config share channels up to maxStreamsPerConn before a new one is opened.
+// This mirrors gocql's per-host connection-pool fan-out.
 type PooledClient struct {
-	client   *Client
-	lastUsed time.Time
+	mu       sync.Mutex
 	key      string
-	inUse    atomic.Bool // Track if connection is actively streaming
+	channels []*pooledChannel
 }
 
 // Pool manages reusable Flight SQL connections
 type Pool struct {
-	mu      sync.Mutex
-	clients map[string]*PooledClient
-	maxIdle time.Duration
+	mu                 sync.Mutex
+	clients            map[string]*PooledClient
+	maxIdle            time.Duration
+	maxIdleConns       int // 0 means unlimited idle channels per config
+	maxLifetime        time.Duration
+	healthCheckPeriod  time.Duration
+	healthCheckTimeout time.Duration
+	maxStreamsPerConn  int
+	stopHealthCheck    chan struct{}
+	healthCheckDone    chan struct{}
+	stopOnce           sync.Once
+
+	acquired atomic.Int64 // currently leased channels, pool-wide
+	evicted  atomic.Int64 // cumulative channels reaped since pool start
+}
+
+// PoolOptions configures a Pool at construction time via NewPoolWithOptions.
+// Zero-valued fields fall back to the same defaults NewPool uses, except
+// MaxIdle and MaxLifetime which default to unlimited.
+type PoolOptions struct {
+	// MaxIdle caps how many idle channels a single (uri, credentials) config
+	// may keep parked; 0 means unlimited, mirroring database/sql's
+	// SetMaxIdleConns default.
+	MaxIdle int
+	// MaxIdleTime is how long a pooled channel may sit unused before the
+	// reaper evicts it.
+	MaxIdleTime time.Duration
+	// MaxLifetime caps how long a pooled channel may live regardless of
+	// activity, forcing periodic rotation of gRPC subchannels. 0 means
+	// unlimited.
+	MaxLifetime time.Duration
+	// HealthInterval is how often the reaper wakes to probe idle channels.
+	HealthInterval time.Duration
+	// HealthTimeout bounds how long a single liveness probe may take.
+	HealthTimeout time.Duration
+}
+
+// Lease identifies the pooled channel a ConnectionResult borrowed, so
+// ReleaseConnection can decrement that channel's in-flight count directly
+// instead of re-deriving it from a Config.
+type Lease struct {
+	key string
+	ch  *pooledChannel
+	pc  *PooledClient
+}
+
+// PoolStats is a point-in-time snapshot of a Pool's channel accounting,
+// useful for dashboards and tests that assert the reaper is doing its job.
+type PoolStats struct {
+	Acquired int   // channels currently leased out
+	Idle     int   // channels sitting idle, available for the next Get
+	Evicted  int64 // cumulative channels reaped since the pool was created
 }
 
 // ConnectionResult holds a connection and whether it came from the pool
 type ConnectionResult struct {
 	Client   *Client
-	IsPooled bool // If true, use ReleaseConnection; if false, use Client.Close()
+	IsPooled bool   // If true, use ReleaseConnection(Lease); if false, use Client.Close()
+	Lease    *Lease // Non-nil iff IsPooled
 }
 
 // Global pool instance
 var globalPool = NewPool()
 
-// NewPool creates a new connection pool
+// NewPool creates a new connection pool and starts its background
+// health-check/idle-eviction loop, using the package defaults for every
+// tunable. Use NewPoolWithOptions to override them.
 func NewPool() *Pool {
-	return &Pool{
-		clients: make(map[string]*PooledClient),
-		maxIdle: 5 * time.Minute, // Default idle timeout
+	return NewPoolWithOptions(PoolOptions{})
+}
+
+// NewPoolWithOptions creates a new connection pool with explicit lifecycle
+// tunables and starts its background health-check/idle-eviction loop. Zero
+// values for MaxIdleTime, HealthInterval and HealthTimeout fall back to the
+// package defaults; MaxIdle and MaxLifetime default to unlimited.
+func NewPoolWithOptions(opts PoolOptions) *Pool {
+	if opts.MaxIdleTime <= 0 {
+		opts.MaxIdleTime = 5 * time.Minute
+	}
+	if opts.HealthInterval <= 0 {
+		opts.HealthInterval = defaultHealthCheckInterval
+	}
+	if opts.HealthTimeout <= 0 {
+		opts.HealthTimeout = defaultHealthCheckTimeout
+	}
+
+	p := &Pool{
+		clients:            make(map[string]*PooledClient),
+		maxIdle:            opts.MaxIdleTime,
+		maxIdleConns:       opts.MaxIdle,
+		maxLifetime:        opts.MaxLifetime,
+		healthCheckPeriod:  opts.HealthInterval,
+		healthCheckTimeout: opts.HealthTimeout,
+		maxStreamsPerConn:  defaultMaxStreamsPerConn,
+		stopHealthCheck:    make(chan struct{}),
+		healthCheckDone:    make(chan struct{}),
+	}
+	go p.healthCheckLoop()
+	return p
+}
+
+// SetHealthCheckInterval changes how often the background reaper probes idle
+// pooled connections. Safe to call while the loop is running; it takes effect
+// on the next tick.
+func (p *Pool) SetHealthCheckInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthCheckPeriod = d
+}
+
+// SetMaxIdle changes how long an idle pooled connection may sit before it is
+// considered stale and evicted.
+func (p *Pool) SetMaxIdle(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxIdle = d
+}
+
+// SetMaxStreamsPerConn changes how many concurrent leases a single pooled
+// gRPC channel may carry before Get opens a new one for the same config.
+func (p *Pool) SetMaxStreamsPerConn(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxStreamsPerConn = n
+}
+
+// SetMaxIdleConns changes how many idle channels a single config may keep
+// parked before Release starts closing the oldest excess ones. 0 means
+// unlimited.
+func (p *Pool) SetMaxIdleConns(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxIdleConns = n
+}
+
+// SetMaxLifetime changes how long a pooled channel may live, regardless of
+// activity, before the reaper retires it. 0 means unlimited.
+func (p *Pool) SetMaxLifetime(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxLifetime = d
+}
+
+// Stop cancels the background health-check loop. The pool itself remains
+// usable afterwards (Get/Release/Close still work); it just stops proactively
+// evicting idle connections.
+func (p *Pool) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopHealthCheck)
+	})
+	<-p.healthCheckDone
+}
+
+// healthCheckLoop periodically probes idle pooled channels and evicts
+// entries that fail the probe or have exceeded maxIdle. Modeled on gocql's
+// connection-keepalive pattern: a single goroutine per pool, woken on a
+// configurable interval.
+func (p *Pool) healthCheckLoop() {
+	defer close(p.healthCheckDone)
+
+	for {
+		p.mu.Lock()
+		period := p.healthCheckPeriod
+		p.mu.Unlock()
+
+		timer := time.NewTimer(period)
+		select {
+		case <-p.stopHealthCheck:
+			timer.Stop()
+			return
+		case <-timer.C:
+			p.runHealthCheck()
+		}
+	}
+}
+
+// runHealthCheck walks every pooled client once, probing idle channels and
+// reaping ones that fail the probe or have sat idle past maxIdle, then rolls
+// back any transaction duckarrow_begin pinned but nothing has touched in a
+// while (see reapAbandonedTransactions).
+func (p *Pool) runHealthCheck() {
+	p.mu.Lock()
+	pcs := make([]*PooledClient, 0, len(p.clients))
+	for _, pc := range p.clients {
+		pcs = append(pcs, pc)
+	}
+	p.mu.Unlock()
+
+	for _, pc := range pcs {
+		p.reapChannels(pc)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.healthCheckTimeout)
+	defer cancel()
+	reapAbandonedTransactions(ctx)
+}
+
+// reapChannels probes pc's idle channels and drops the ones that fail the
+// probe or have exceeded maxIdle, closing their underlying connections.
+// Channels are reserved (via tryReserveForProbe) before the probe runs so a
+// concurrent Get can't be handed a channel that's about to be closed out
+// from under it. If every channel is reaped, the PooledClient entry itself
+// is removed from the pool so the next Get starts fresh.
+func (p *Pool) reapChannels(pc *PooledClient) {
+	pc.mu.Lock()
+	candidates := make([]*pooledChannel, 0, len(pc.channels))
+	for _, ch := range pc.channels {
+		if ch.tryReserveForProbe() {
+			candidates = append(candidates, ch)
+		}
+	}
+	pc.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	dead := make(map[*pooledChannel]bool)
+	for _, ch := range candidates {
+		stale := ch.idleFor() > p.maxIdle
+		expired := p.maxLifetime > 0 && ch.age() > p.maxLifetime
+		if stale || expired || !p.probe(ch.client) {
+			dead[ch] = true
+			continue
+		}
+		ch.inFlight.Store(0)
+	}
+
+	if len(dead) == 0 {
+		return
+	}
+	p.evicted.Add(int64(len(dead)))
+
+	pc.mu.Lock()
+	kept := pc.channels[:0]
+	for _, ch := range pc.channels {
+		if !dead[ch] {
+			kept = append(kept, ch)
+		}
+	}
+	pc.channels = kept
+	empty := len(pc.channels) == 0
+	pc.mu.Unlock()
+
+	for ch := range dead {
+		ch.client.Close()
+	}
+
+	if empty {
+		p.mu.Lock()
+		if existing, ok := p.clients[pc.key]; ok && existing == pc {
+			delete(p.clients, pc.key)
+		}
+		p.mu.Unlock()
 	}
 }
 
+// probe performs a lightweight liveness check against the Flight SQL server
+// with a short deadline. It reuses IsHealthy's nil-check and, when the client
+// looks structurally sound, issues a cheap metadata call that the server must
+// answer even if it has nothing to return. It deliberately calls
+// getSchemasUncached rather than GetSchemas: GetSchemas can be served out of
+// the process-wide metadata cache, which would let a connection whose socket
+// has actually died "pass" for up to the cache TTL just because some other
+// client refreshed the same key recently.
+func (p *Pool) probe(c *Client) bool {
+	if !c.IsHealthy() {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.healthCheckTimeout)
+	defer cancel()
+	_, err := c.getSchemasUncached(ctx)
+	return err == nil
+}
+
 // GetConnection gets a connection from the pool or creates a new one.
 // Returns ConnectionResult which indicates whether to use ReleaseConnection or Close.
 func GetConnection(ctx context.Context, cfg Config) (*ConnectionResult, error) {
 	return globalPool.Get(ctx, cfg)
 }
 
-// ReleaseConnection returns a pooled connection to the pool.
-// Only call this for connections where ConnectionResult.IsPooled is true.
-func ReleaseConnection(cfg Config) {
-	globalPool.Release(cfg)
+// ReleaseConnection returns a pooled channel (identified by lease) to the
+// pool. Only call this for connections where ConnectionResult.IsPooled is
+// true, passing back that same ConnectionResult.Lease.
+func ReleaseConnection(lease *Lease) {
+	globalPool.Release(lease)
+}
+
+// ReservePinnedConnection marks lease's channel as exclusively reserved so
+// Get won't hand it to another caller. Only call this for connections where
+// ConnectionResult.IsPooled is true; see Pool.ReservePinned.
+func ReservePinnedConnection(lease *Lease) {
+	globalPool.ReservePinned(lease)
 }
 
 // ClosePool closes all pooled connections
@@ -59,7 +383,46 @@ func ClosePool() {
 	globalPool.Close()
 }
 
-// configKey generates a unique key using null-byte delimiters to prevent collisions
+// SetPoolHealthCheckInterval tunes how often the global pool's background
+// reaper probes idle connections.
+func SetPoolHealthCheckInterval(d time.Duration) {
+	globalPool.SetHealthCheckInterval(d)
+}
+
+// SetPoolMaxIdle tunes how long an idle connection may sit in the global pool
+// before it's considered stale.
+func SetPoolMaxIdle(d time.Duration) {
+	globalPool.SetMaxIdle(d)
+}
+
+// SetPoolMaxStreamsPerConn tunes how many concurrent leases the global
+// pool allows on a single gRPC channel before fanning out to another one.
+func SetPoolMaxStreamsPerConn(n int) {
+	globalPool.SetMaxStreamsPerConn(n)
+}
+
+// SetPoolMaxIdleConns tunes how many idle channels the global pool keeps
+// parked per config before closing the oldest excess ones on Release.
+func SetPoolMaxIdleConns(n int) {
+	globalPool.SetMaxIdleConns(n)
+}
+
+// SetPoolMaxLifetime tunes how long a pooled channel in the global pool may
+// live, regardless of activity, before the reaper retires it.
+func SetPoolMaxLifetime(d time.Duration) {
+	globalPool.SetMaxLifetime(d)
+}
+
+// GetPoolStats returns a point-in-time snapshot of the global pool's
+// channel accounting.
+func GetPoolStats() PoolStats {
+	return globalPool.Stats()
+}
+
+// configKey generates a unique key using null-byte delimiters to prevent
+// collisions. Every field that Connect actually uses to shape the
+// connection must be included here, or two configs that differ only in
+// auth/TLS would alias onto the same pooled client.
 func (p *Pool) configKey(cfg Config) string {
 	h := sha256.New()
 	h.Write([]byte(cfg.URI))
@@ -69,77 +432,196 @@ func (p *Pool) configKey(cfg Config) string {
 	h.Write([]byte(cfg.Password))
 	h.Write([]byte{0})
 	h.Write([]byte(fmt.Sprintf("%v", cfg.SkipVerify)))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.BearerToken))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.TLSCACert))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.TLSClientCert))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.TLSClientKey))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.ServerNameOverride))
+	h.Write([]byte{0})
+	// OAuth2TokenSource is a func value and can't be hashed meaningfully;
+	// record only whether one is present so configs with/without a token
+	// source never alias each other.
+	h.Write([]byte(fmt.Sprintf("%v", cfg.OAuth2TokenSource != nil)))
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// Get retrieves a connection from the pool or creates a new one
+// Get returns a connection from the pool, fanning out across channels for
+// the same config rather than forcing a concurrent caller onto an unmanaged
+// one-off connection. It picks the least-loaded channel below
+// maxStreamsPerConn, skipping any channel reserved via ReservePinned; only
+// when every remaining channel is saturated or reserved (or none exist yet)
+// does it dial a new one. A channel handed out here may still be shared
+// with other concurrent callers - Client itself serializes the underlying
+// adbc.Connection/Statement calls (see Client.mu in client.go).
 func (p *Pool) Get(ctx context.Context, cfg Config) (*ConnectionResult, error) {
 	key := p.configKey(cfg)
 
 	p.mu.Lock()
-	defer p.mu.Unlock()
+	pc, ok := p.clients[key]
+	if !ok {
+		pc = &PooledClient{key: key}
+		p.clients[key] = pc
+	}
+	maxStreams := p.maxStreamsPerConn
+	p.mu.Unlock()
 
-	if pc, ok := p.clients[key]; ok {
-		// Case 1: Connection in use - create new unmanaged connection
-		if pc.inUse.Load() {
-			client, err := Connect(ctx, cfg)
-			if err != nil {
-				return nil, err
-			}
-			// Return as non-pooled - caller must Close() directly
-			return &ConnectionResult{Client: client, IsPooled: false}, nil
-		}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
 
-		// Case 2: Connection not in use - check health and staleness
-		if pc.client.IsHealthy() && time.Since(pc.lastUsed) < p.maxIdle {
-			// Healthy and fresh - reuse
-			pc.inUse.Store(true)
-			pc.lastUsed = time.Now()
-			return &ConnectionResult{Client: pc.client, IsPooled: true}, nil
+	var best *pooledChannel
+	for _, ch := range pc.channels {
+		load := ch.inFlight.Load()
+		if load < 0 || int(load) >= maxStreams || ch.pinned.Load() || !ch.client.IsHealthy() {
+			continue
 		}
+		if best == nil || load < best.inFlight.Load() {
+			best = ch
+		}
+	}
 
-		// Case 3: Unhealthy or stale - close and remove
-		pc.client.Close()
-		delete(p.clients, key)
+	if best == nil {
+		client, err := Connect(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		best = newPooledChannel(client)
+		pc.channels = append(pc.channels, best)
 	}
 
-	// Create new connection and add to pool
-	client, err := Connect(ctx, cfg)
-	if err != nil {
-		return nil, err
+	best.inFlight.Add(1)
+	best.touch()
+	p.acquired.Add(1)
+
+	return &ConnectionResult{
+		Client:   best.client,
+		IsPooled: true,
+		Lease:    &Lease{key: key, ch: best, pc: pc},
+	}, nil
+}
+
+// Release returns a leased channel to the pool, decrementing its in-flight
+// count so a subsequent Get can pick it again, then trims any idle channels
+// beyond maxIdleConns for that config. Also clears any pin set by
+// ReservePinned, a no-op if the channel wasn't pinned.
+func (p *Pool) Release(lease *Lease) {
+	if lease == nil || lease.ch == nil {
+		return
 	}
+	lease.ch.inFlight.Add(-1)
+	lease.ch.pinned.Store(false)
+	lease.ch.touch()
+	p.acquired.Add(-1)
 
-	pc := &PooledClient{
-		client:   client,
-		lastUsed: time.Now(),
-		key:      key,
+	if lease.pc != nil {
+		p.trimIdleChannels(lease.pc)
 	}
-	pc.inUse.Store(true)
-	p.clients[key] = pc
+}
 
-	return &ConnectionResult{Client: client, IsPooled: true}, nil
+// ReservePinned marks lease's channel as exclusively reserved, excluding it
+// from Get's least-loaded scan entirely (regardless of inFlight count) until
+// the lease is returned via Release. Used for a connection pinned to an open
+// transaction (see PinTransaction): without this, Get's fan-out would still
+// be free to hand the same channel to an unrelated caller as long as
+// inFlight stayed below maxStreamsPerConn, joining its statements into
+// someone else's open transaction.
+func (p *Pool) ReservePinned(lease *Lease) {
+	if lease == nil || lease.ch == nil {
+		return
+	}
+	lease.ch.pinned.Store(true)
 }
 
-// Release marks a pooled connection as available for reuse
-func (p *Pool) Release(cfg Config) {
-	key := p.configKey(cfg)
+// trimIdleChannels closes the oldest idle channels on pc down to
+// maxIdleConns, mirroring database/sql's SetMaxIdleConns behavior. A no-op
+// when maxIdleConns is unlimited (0).
+func (p *Pool) trimIdleChannels(pc *PooledClient) {
+	if p.maxIdleConns <= 0 {
+		return
+	}
+
+	pc.mu.Lock()
+	idle := make([]*pooledChannel, 0, len(pc.channels))
+	for _, ch := range pc.channels {
+		if ch.inFlight.Load() == 0 {
+			idle = append(idle, ch)
+		}
+	}
+	if len(idle) <= p.maxIdleConns {
+		pc.mu.Unlock()
+		return
+	}
+	sort.Slice(idle, func(i, j int) bool {
+		return idle[i].lastActive.Load() < idle[j].lastActive.Load()
+	})
 
+	excess := make(map[*pooledChannel]bool)
+	for _, ch := range idle[:len(idle)-p.maxIdleConns] {
+		if ch.tryReserveForProbe() {
+			excess[ch] = true
+		}
+	}
+
+	kept := pc.channels[:0]
+	for _, ch := range pc.channels {
+		if !excess[ch] {
+			kept = append(kept, ch)
+		}
+	}
+	pc.channels = kept
+	pc.mu.Unlock()
+
+	for ch := range excess {
+		ch.client.Close()
+	}
+	p.evicted.Add(int64(len(excess)))
+}
+
+// Stats returns a point-in-time snapshot of the pool's channel accounting.
+func (p *Pool) Stats() PoolStats {
 	p.mu.Lock()
-	defer p.mu.Unlock()
+	pcs := make([]*PooledClient, 0, len(p.clients))
+	for _, pc := range p.clients {
+		pcs = append(pcs, pc)
+	}
+	p.mu.Unlock()
+
+	idle := 0
+	for _, pc := range pcs {
+		pc.mu.Lock()
+		for _, ch := range pc.channels {
+			if ch.inFlight.Load() == 0 {
+				idle++
+			}
+		}
+		pc.mu.Unlock()
+	}
 
-	if pc, ok := p.clients[key]; ok {
-		pc.lastUsed = time.Now()
-		pc.inUse.Store(false)
+	return PoolStats{
+		Acquired: int(p.acquired.Load()),
+		Idle:     idle,
+		Evicted:  p.evicted.Load(),
 	}
 }
 
-// Close closes all connections in the pool
+// Close stops the background health-check loop and closes all connections in
+// the pool.
 func (p *Pool) Close() {
+	p.Stop()
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for key, pc := range p.clients {
-		pc.client.Close()
+		pc.mu.Lock()
+		for _, ch := range pc.channels {
+			ch.client.Close()
+		}
+		pc.mu.Unlock()
 		delete(p.clients, key)
 	}
 }
@@ -1,8 +1,10 @@
 package flight
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestConfigKey(t *testing.T) {
@@ -50,6 +52,30 @@ func TestConfigKey(t *testing.T) {
 			cfg2:        Config{},
 			shouldMatch: true,
 		},
+		{
+			name:        "different bearer token different key",
+			cfg1:        Config{URI: "grpc://localhost:31337", BearerToken: "token-a"},
+			cfg2:        Config{URI: "grpc://localhost:31337", BearerToken: "token-b"},
+			shouldMatch: false,
+		},
+		{
+			name:        "different TLS client cert different key",
+			cfg1:        Config{URI: "grpc://localhost:31337", TLSClientCert: "a.pem", TLSClientKey: "a.key"},
+			cfg2:        Config{URI: "grpc://localhost:31337", TLSClientCert: "b.pem", TLSClientKey: "a.key"},
+			shouldMatch: false,
+		},
+		{
+			name:        "different server name override different key",
+			cfg1:        Config{URI: "grpc://localhost:31337", ServerNameOverride: "a.example.com"},
+			cfg2:        Config{URI: "grpc://localhost:31337", ServerNameOverride: "b.example.com"},
+			shouldMatch: false,
+		},
+		{
+			name:        "presence of OAuth2TokenSource differs",
+			cfg1:        Config{URI: "grpc://localhost:31337"},
+			cfg2:        Config{URI: "grpc://localhost:31337", OAuth2TokenSource: func(ctx context.Context) (string, error) { return "tok", nil }},
+			shouldMatch: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,6 +147,39 @@ func TestClientIsHealthy(t *testing.T) {
 	// uses `c.conn != nil && c.db != nil`, so both must be non-nil to return true.
 }
 
+func TestPooledChannelReserveForProbe(t *testing.T) {
+	ch := newPooledChannel(&Client{})
+
+	if !ch.tryReserveForProbe() {
+		t.Fatal("expected to reserve an idle channel for probing")
+	}
+	if ch.tryReserveForProbe() {
+		t.Error("expected a second reservation attempt on an already-reserved channel to fail")
+	}
+
+	// A channel with an active lease must not be reservable.
+	ch2 := newPooledChannel(&Client{})
+	ch2.inFlight.Store(1)
+	if ch2.tryReserveForProbe() {
+		t.Error("expected an in-flight channel to reject probe reservation")
+	}
+}
+
+func TestPoolSetMaxStreamsPerConn(t *testing.T) {
+	pool := NewPool()
+	defer pool.Close()
+
+	pool.SetMaxStreamsPerConn(7)
+
+	pool.mu.Lock()
+	got := pool.maxStreamsPerConn
+	pool.mu.Unlock()
+
+	if got != 7 {
+		t.Errorf("maxStreamsPerConn = %d, want 7", got)
+	}
+}
+
 func TestNewPool(t *testing.T) {
 	pool := NewPool()
 
@@ -193,13 +252,8 @@ func TestPoolReleaseConcurrent(t *testing.T) {
 	for i := 0; i < numGoroutines; i++ {
 		go func(id int) {
 			defer wg.Done()
-			cfg := Config{
-				URI:      "grpc://localhost:31337",
-				Username: "user",
-				Password: "pass",
-			}
 			for j := 0; j < numIterations; j++ {
-				pool.Release(cfg) // Release on non-existent key should be safe
+				pool.Release(nil) // Release of a nil lease should be safe
 			}
 		}(i)
 	}
@@ -207,23 +261,195 @@ func TestPoolReleaseConcurrent(t *testing.T) {
 	wg.Wait()
 }
 
-func TestPoolReleaseUnknownKey(t *testing.T) {
-	// Test that Release() with a config that was never in the pool doesn't panic
+func TestPoolReleaseNilLease(t *testing.T) {
+	// Test that Release() with a nil lease doesn't panic
 	pool := NewPool()
 	defer pool.Close()
 
-	unknownConfig := Config{
-		URI:      "grpc://unknown:9999",
-		Username: "nobody",
-		Password: "nothing",
-	}
-
 	// Should not panic or cause any issues
-	pool.Release(unknownConfig)
+	pool.Release(nil)
 
 	// Pool should still be empty
 	if len(pool.clients) != 0 {
-		t.Errorf("expected empty pool after releasing unknown config, got %d entries", len(pool.clients))
+		t.Errorf("expected empty pool after releasing nil lease, got %d entries", len(pool.clients))
+	}
+}
+
+func TestPoolStopIdempotent(t *testing.T) {
+	// Stop() must be safe to call multiple times (e.g. once explicitly and
+	// again via Close()).
+	pool := NewPool()
+	pool.Stop()
+	pool.Stop()
+}
+
+func TestPoolSetHealthCheckIntervalAndMaxIdle(t *testing.T) {
+	pool := NewPool()
+	defer pool.Close()
+
+	pool.SetHealthCheckInterval(time.Millisecond)
+	pool.SetMaxIdle(time.Nanosecond)
+
+	pool.mu.Lock()
+	gotInterval := pool.healthCheckPeriod
+	gotMaxIdle := pool.maxIdle
+	pool.mu.Unlock()
+
+	if gotInterval != time.Millisecond {
+		t.Errorf("healthCheckPeriod = %v, want %v", gotInterval, time.Millisecond)
+	}
+	if gotMaxIdle != time.Nanosecond {
+		t.Errorf("maxIdle = %v, want %v", gotMaxIdle, time.Nanosecond)
+	}
+}
+
+func TestPoolRunHealthCheckEvictsUnhealthyChannel(t *testing.T) {
+	// A nil-backed channel should fail IsHealthy() and be evicted by the
+	// health check without panicking, even though it has no real connection.
+	pool := NewPool()
+	defer pool.Close()
+
+	pool.SetMaxIdle(time.Hour)
+
+	pc := &PooledClient{key: "test-key"}
+	pc.channels = append(pc.channels, newPooledChannel(&Client{}))
+	pool.mu.Lock()
+	pool.clients["test-key"] = pc
+	pool.mu.Unlock()
+
+	pool.runHealthCheck()
+
+	pool.mu.Lock()
+	_, stillPresent := pool.clients["test-key"]
+	pool.mu.Unlock()
+
+	if stillPresent {
+		t.Error("expected unhealthy channel's entry to be evicted by runHealthCheck")
+	}
+}
+
+func TestNewPoolWithOptionsDefaults(t *testing.T) {
+	pool := NewPoolWithOptions(PoolOptions{})
+	defer pool.Close()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.maxIdle != 5*time.Minute {
+		t.Errorf("maxIdle = %v, want default 5m", pool.maxIdle)
+	}
+	if pool.healthCheckPeriod != defaultHealthCheckInterval {
+		t.Errorf("healthCheckPeriod = %v, want default %v", pool.healthCheckPeriod, defaultHealthCheckInterval)
+	}
+	if pool.healthCheckTimeout != defaultHealthCheckTimeout {
+		t.Errorf("healthCheckTimeout = %v, want default %v", pool.healthCheckTimeout, defaultHealthCheckTimeout)
+	}
+	if pool.maxIdleConns != 0 {
+		t.Errorf("maxIdleConns = %d, want 0 (unlimited)", pool.maxIdleConns)
+	}
+	if pool.maxLifetime != 0 {
+		t.Errorf("maxLifetime = %v, want 0 (unlimited)", pool.maxLifetime)
+	}
+}
+
+func TestNewPoolWithOptionsOverrides(t *testing.T) {
+	pool := NewPoolWithOptions(PoolOptions{
+		MaxIdle:        3,
+		MaxIdleTime:    time.Minute,
+		MaxLifetime:    time.Hour,
+		HealthInterval: 10 * time.Second,
+		HealthTimeout:  time.Second,
+	})
+	defer pool.Close()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.maxIdleConns != 3 {
+		t.Errorf("maxIdleConns = %d, want 3", pool.maxIdleConns)
+	}
+	if pool.maxIdle != time.Minute {
+		t.Errorf("maxIdle = %v, want 1m", pool.maxIdle)
+	}
+	if pool.maxLifetime != time.Hour {
+		t.Errorf("maxLifetime = %v, want 1h", pool.maxLifetime)
+	}
+	if pool.healthCheckPeriod != 10*time.Second {
+		t.Errorf("healthCheckPeriod = %v, want 10s", pool.healthCheckPeriod)
+	}
+	if pool.healthCheckTimeout != time.Second {
+		t.Errorf("healthCheckTimeout = %v, want 1s", pool.healthCheckTimeout)
+	}
+}
+
+func TestPoolRunHealthCheckEvictsExpiredLifetime(t *testing.T) {
+	// A channel older than maxLifetime should be evicted even though it's
+	// well within maxIdle. The zero Client here also fails IsHealthy(), so
+	// the health probe alone would evict it too.
+	pool := NewPool()
+	defer pool.Close()
+
+	pool.SetMaxIdle(time.Hour)
+	pool.SetMaxLifetime(time.Nanosecond)
+
+	pc := &PooledClient{key: "test-key"}
+	pc.channels = append(pc.channels, newPooledChannel(&Client{}))
+	pool.mu.Lock()
+	pool.clients["test-key"] = pc
+	pool.mu.Unlock()
+
+	pool.runHealthCheck()
+
+	pool.mu.Lock()
+	_, stillPresent := pool.clients["test-key"]
+	pool.mu.Unlock()
+
+	if stillPresent {
+		t.Error("expected channel past maxLifetime to be evicted by runHealthCheck")
+	}
+	if pool.Stats().Evicted != 1 {
+		t.Errorf("Evicted = %d, want 1", pool.Stats().Evicted)
+	}
+}
+
+func TestPoolTrimIdleChannels(t *testing.T) {
+	pool := NewPool()
+	defer pool.Close()
+	pool.SetMaxIdleConns(1)
+
+	pc := &PooledClient{key: "test-key"}
+	older := newPooledChannel(&Client{})
+	older.lastActive.Store(1)
+	newer := newPooledChannel(&Client{})
+	newer.lastActive.Store(2)
+	pc.channels = append(pc.channels, older, newer)
+
+	pool.trimIdleChannels(pc)
+
+	if len(pc.channels) != 1 {
+		t.Fatalf("expected 1 channel to remain, got %d", len(pc.channels))
+	}
+	if pc.channels[0] != newer {
+		t.Error("expected the most recently active channel to survive trimming")
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	pool := NewPool()
+	defer pool.Close()
+
+	pc := &PooledClient{key: "test-key"}
+	pc.channels = append(pc.channels, newPooledChannel(&Client{}))
+	pool.mu.Lock()
+	pool.clients["test-key"] = pc
+	pool.mu.Unlock()
+
+	stats := pool.Stats()
+	if stats.Idle != 1 {
+		t.Errorf("Idle = %d, want 1", stats.Idle)
+	}
+	if stats.Acquired != 0 {
+		t.Errorf("Acquired = %d, want 0", stats.Acquired)
 	}
 }
 
@@ -238,14 +464,14 @@ func TestPoolConcurrentCloseAndRelease(t *testing.T) {
 	go func() {
 		defer wg.Done()
 		for i := 0; i < 100; i++ {
-			pool.Release(Config{URI: "grpc://test:1234"})
+			pool.Release(nil)
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
 		for i := 0; i < 100; i++ {
-			pool.Release(Config{URI: "grpc://test:5678"})
+			pool.Release(nil)
 		}
 	}()
 
@@ -0,0 +1,143 @@
+package flight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ParseNamedParams rewrites a SQL string's named placeholders (":name") into
+// the positional "$1", "$2", ... form the Flight SQL prepared-statement path
+// (ExecutePrepared/buildParamRecord) expects, and returns the parameter names
+// in the order their positions were assigned. A name repeated later in the
+// query reuses its first-assigned position, matching how SQL engines treat
+// a repeated named bind as the same parameter.
+//
+// Flight SQL itself has no notion of named binds; this translation lets
+// QueryNamedPrepared take a map of named values while still going through
+// the existing positional bind/Arrow-record machinery. No SQL-level function
+// calls QueryNamedPrepared, so there is no way to pass it named values
+// from a DuckDB query.
+func ParseNamedParams(sql string) (rewritten string, names []string) {
+	var b strings.Builder
+	positions := make(map[string]int)
+
+	inQuote := byte(0)
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if inQuote != 0 {
+			b.WriteByte(c)
+			if c == inQuote {
+				inQuote = 0
+			}
+			continue
+		}
+		if c == '\'' || c == '"' {
+			inQuote = c
+			b.WriteByte(c)
+			continue
+		}
+		if c != ':' || i+1 >= len(sql) || !isNameStart(sql[i+1]) {
+			b.WriteByte(c)
+			continue
+		}
+
+		j := i + 1
+		for j < len(sql) && isNameChar(sql[j]) {
+			j++
+		}
+		name := sql[i+1 : j]
+
+		pos, ok := positions[name]
+		if !ok {
+			names = append(names, name)
+			pos = len(names)
+			positions[name] = pos
+		}
+		fmt.Fprintf(&b, "$%d", pos)
+		i = j - 1
+	}
+
+	return b.String(), names
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// ParseQueryParams rewrites sql's placeholders into the positional "$1",
+// "$2", ... form ExecutePrepared/buildParamRecord expect, accepting
+// whichever style the caller used: named (":name"), ODBC-style ("?"), or a
+// query that already uses "$N" directly. Named placeholders return the
+// parameter names in bind order, exactly like ParseNamedParams; "?"/"$N"
+// queries return a nil names slice since their parameters are already
+// ordered positionally and need no name-to-position lookup.
+//
+// This backs flight_query (duckarrow_go_query_bind), which - unlike
+// QueryNamedPrepared's fixed named-struct argument - accepts whichever
+// placeholder style the caller's SQL happens to use.
+func ParseQueryParams(sql string) (rewritten string, names []string) {
+	rewritten, names = ParseNamedParams(sql)
+	if len(names) > 0 {
+		return rewritten, names
+	}
+	return rewriteQuestionMarks(rewritten), nil
+}
+
+// rewriteQuestionMarks replaces each ODBC-style "?" placeholder with the
+// next "$N" position, left to right. Quoted text is left untouched, and any
+// placeholder already in "$N" form passes through unchanged since it never
+// matches '?'.
+func rewriteQuestionMarks(sql string) string {
+	var b strings.Builder
+	inQuote := byte(0)
+	n := 0
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if inQuote != 0 {
+			b.WriteByte(c)
+			if c == inQuote {
+				inQuote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			inQuote = c
+			b.WriteByte(c)
+		case '?':
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// QueryNamedPrepared runs sql as a cached prepared statement, binding named
+// parameters by first rewriting ":name" placeholders into the positional
+// form ExecutePrepared expects. Returns an error if the query references a
+// name not present in named.
+func (c *Client) QueryNamedPrepared(ctx context.Context, sql string, named map[string]any) (*QueryResult, error) {
+	rewritten, names := ParseNamedParams(sql)
+
+	params := make([]any, len(names))
+	for i, name := range names {
+		v, ok := named[name]
+		if !ok {
+			return nil, fmt.Errorf("missing value for named parameter %q", name)
+		}
+		params[i] = v
+	}
+
+	return c.ExecutePrepared(ctx, rewritten, params)
+}
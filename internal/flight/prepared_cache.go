@@ -0,0 +1,165 @@
+package flight
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// defaultPreparedCacheSize is the number of prepared statements kept per
+// connection when the cache size hasn't been explicitly configured.
+const defaultPreparedCacheSize = 100
+
+// preparedCacheSize and preparedCacheEnabled are process-wide defaults,
+// tunable at runtime via duckarrow_configure_option('prepared_cache_size', ...)
+// and duckarrow_configure_option('prepared_cache_enabled', ...). New clients
+// pick up the current value at Connect() time.
+var (
+	preparedCacheSize    atomic.Int64
+	preparedCacheEnabled atomic.Bool
+)
+
+func init() {
+	preparedCacheSize.Store(defaultPreparedCacheSize)
+	preparedCacheEnabled.Store(true)
+}
+
+// SetPreparedCacheSize changes the per-connection prepared-statement LRU
+// capacity used by clients created after this call.
+func SetPreparedCacheSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	preparedCacheSize.Store(int64(size))
+}
+
+// SetPreparedCacheEnabled toggles whether new clients maintain a
+// prepared-statement cache at all.
+func SetPreparedCacheEnabled(enabled bool) {
+	preparedCacheEnabled.Store(enabled)
+}
+
+// preparedEntry holds a cached Flight SQL prepared statement handle plus the
+// Arrow schema it was prepared against.
+type preparedEntry struct {
+	sql    string
+	stmt   adbc.Statement
+	schema *arrow.Schema
+}
+
+// PreparedCache is a bounded LRU over prepared statement handles for a single
+// connection, modeled on gocql's per-connection prepared statement cache.
+// Entries are keyed by the SQL text; eviction closes the underlying
+// adbc.Statement so the server can free its resources.
+type PreparedCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front = most recently used
+}
+
+// NewPreparedCache creates a prepared-statement cache with the given maximum
+// number of entries. A non-positive capacity disables caching.
+func NewPreparedCache(capacity int) *PreparedCache {
+	return &PreparedCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached entry for sql, promoting it to most-recently-used.
+func (c *PreparedCache) Get(sql string) (*preparedEntry, bool) {
+	if c == nil || c.capacity <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[sql]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*preparedEntry), true
+}
+
+// Put inserts or replaces the cached entry for sql, evicting the
+// least-recently-used entry if the cache is at capacity.
+func (c *PreparedCache) Put(entry *preparedEntry) {
+	if c == nil || c.capacity <= 0 {
+		if entry != nil && entry.stmt != nil {
+			entry.stmt.Close()
+		}
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.sql]; ok {
+		old := elem.Value.(*preparedEntry)
+		if old.stmt != nil {
+			old.stmt.Close()
+		}
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(entry)
+	c.entries[entry.sql] = elem
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.evictElement(oldest)
+	}
+}
+
+// Remove invalidates the cached entry for sql, if any, closing its
+// underlying statement handle. Used when the server reports the prepared
+// statement is no longer known (e.g. after a connection reset).
+func (c *PreparedCache) Remove(sql string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[sql]; ok {
+		c.evictElement(elem)
+	}
+}
+
+// Close evicts every cached entry, closing all underlying statement handles.
+func (c *PreparedCache) Close() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		c.evictElement(c.order.Back())
+	}
+}
+
+// evictElement removes elem from both the map and the list and closes its
+// statement. Callers must hold c.mu.
+func (c *PreparedCache) evictElement(elem *list.Element) {
+	entry := elem.Value.(*preparedEntry)
+	delete(c.entries, entry.sql)
+	c.order.Remove(elem)
+	if entry.stmt != nil {
+		entry.stmt.Close()
+	}
+}
@@ -0,0 +1,111 @@
+package flight
+
+import "testing"
+
+// Note: preparedEntry.stmt is an adbc.Statement, which has a large surface
+// area that isn't practical to fake without a real server (see
+// TestClientIsHealthy for the same constraint on Client). These tests exercise
+// the cache's LRU/eviction bookkeeping with nil statements, which Put/Remove/
+// Close must tolerate.
+
+func TestPreparedCacheGetMiss(t *testing.T) {
+	c := NewPreparedCache(2)
+	if _, ok := c.Get("SELECT 1"); ok {
+		t.Error("expected miss on empty cache")
+	}
+}
+
+func TestPreparedCachePutAndGet(t *testing.T) {
+	c := NewPreparedCache(2)
+	entry := &preparedEntry{sql: "SELECT 1"}
+	c.Put(entry)
+
+	got, ok := c.Get("SELECT 1")
+	if !ok {
+		t.Fatal("expected hit after Put")
+	}
+	if got != entry {
+		t.Error("Get returned a different entry than was Put")
+	}
+}
+
+func TestPreparedCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewPreparedCache(2)
+
+	c.Put(&preparedEntry{sql: "A"})
+	c.Put(&preparedEntry{sql: "B"})
+
+	// Touch A so B becomes the least-recently-used entry.
+	if _, ok := c.Get("A"); !ok {
+		t.Fatal("expected A to be cached")
+	}
+
+	c.Put(&preparedEntry{sql: "C"})
+
+	if _, ok := c.Get("B"); ok {
+		t.Error("expected B to be evicted as least-recently-used")
+	}
+	if _, ok := c.Get("A"); !ok {
+		t.Error("expected A to remain cached")
+	}
+	if _, ok := c.Get("C"); !ok {
+		t.Error("expected C to be cached")
+	}
+}
+
+func TestPreparedCacheZeroCapacityDisabled(t *testing.T) {
+	c := NewPreparedCache(0)
+	c.Put(&preparedEntry{sql: "SELECT 1"})
+
+	if _, ok := c.Get("SELECT 1"); ok {
+		t.Error("zero-capacity cache should never hit")
+	}
+}
+
+func TestPreparedCacheRemove(t *testing.T) {
+	c := NewPreparedCache(2)
+	c.Put(&preparedEntry{sql: "SELECT 1"})
+
+	c.Remove("SELECT 1")
+
+	if _, ok := c.Get("SELECT 1"); ok {
+		t.Error("expected removed entry to miss")
+	}
+}
+
+func TestPreparedCacheReplaceExistingKey(t *testing.T) {
+	c := NewPreparedCache(2)
+	first := &preparedEntry{sql: "SELECT 1"}
+	second := &preparedEntry{sql: "SELECT 1"}
+	c.Put(first)
+	c.Put(second)
+
+	got, ok := c.Get("SELECT 1")
+	if !ok {
+		t.Fatal("expected hit after replace")
+	}
+	if got != second {
+		t.Error("expected replaced entry to be the one returned")
+	}
+}
+
+func TestPreparedCacheClose(t *testing.T) {
+	c := NewPreparedCache(2)
+	c.Put(&preparedEntry{sql: "A"})
+	c.Put(&preparedEntry{sql: "B"})
+
+	c.Close() // must not panic
+
+	if _, ok := c.Get("A"); ok {
+		t.Error("expected Close to empty the cache")
+	}
+}
+
+func TestNilPreparedCacheIsSafe(t *testing.T) {
+	var c *PreparedCache
+	if _, ok := c.Get("x"); ok {
+		t.Error("nil cache should never hit")
+	}
+	c.Remove("x") // must not panic
+	c.Close()     // must not panic
+}
@@ -0,0 +1,110 @@
+package flight
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNamedParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		sql       string
+		wantSQL   string
+		wantNames []string
+	}{
+		{
+			name:      "no named params",
+			sql:       `SELECT * FROM "t" WHERE id = 1`,
+			wantSQL:   `SELECT * FROM "t" WHERE id = 1`,
+			wantNames: nil,
+		},
+		{
+			name:      "single named param",
+			sql:       `SELECT * FROM "t" WHERE id = :id`,
+			wantSQL:   `SELECT * FROM "t" WHERE id = $1`,
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "multiple distinct params in order",
+			sql:       `SELECT * FROM "t" WHERE a = :first AND b = :second`,
+			wantSQL:   `SELECT * FROM "t" WHERE a = $1 AND b = $2`,
+			wantNames: []string{"first", "second"},
+		},
+		{
+			name:      "repeated name reuses position",
+			sql:       `SELECT * FROM "t" WHERE a = :x OR b = :x`,
+			wantSQL:   `SELECT * FROM "t" WHERE a = $1 OR b = $1`,
+			wantNames: []string{"x"},
+		},
+		{
+			name:      "colon inside string literal is untouched",
+			sql:       `SELECT * FROM "t" WHERE label = ':not_a_param' AND id = :id`,
+			wantSQL:   `SELECT * FROM "t" WHERE label = ':not_a_param' AND id = $1`,
+			wantNames: []string{"id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotNames := ParseNamedParams(tt.sql)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("ParseNamedParams(%q) sql = %q, want %q", tt.sql, gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("ParseNamedParams(%q) names = %v, want %v", tt.sql, gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestParseQueryParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		sql       string
+		wantSQL   string
+		wantNames []string
+	}{
+		{
+			name:      "no placeholders",
+			sql:       `SELECT * FROM "t" WHERE id = 1`,
+			wantSQL:   `SELECT * FROM "t" WHERE id = 1`,
+			wantNames: nil,
+		},
+		{
+			name:      "named placeholders take priority",
+			sql:       `SELECT * FROM "t" WHERE a = :first AND b = :second`,
+			wantSQL:   `SELECT * FROM "t" WHERE a = $1 AND b = $2`,
+			wantNames: []string{"first", "second"},
+		},
+		{
+			name:      "question marks rewritten in order",
+			sql:       `SELECT * FROM "t" WHERE a = ? AND b = ?`,
+			wantSQL:   `SELECT * FROM "t" WHERE a = $1 AND b = $2`,
+			wantNames: nil,
+		},
+		{
+			name:      "already-positional sql passes through",
+			sql:       `SELECT * FROM "t" WHERE a = $1 AND b = $2`,
+			wantSQL:   `SELECT * FROM "t" WHERE a = $1 AND b = $2`,
+			wantNames: nil,
+		},
+		{
+			name:      "question mark inside string literal is untouched",
+			sql:       `SELECT * FROM "t" WHERE label = 'what?' AND id = ?`,
+			wantSQL:   `SELECT * FROM "t" WHERE label = 'what?' AND id = $1`,
+			wantNames: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotNames := ParseQueryParams(tt.sql)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("ParseQueryParams(%q) sql = %q, want %q", tt.sql, gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("ParseQueryParams(%q) names = %v, want %v", tt.sql, gotNames, tt.wantNames)
+			}
+		})
+	}
+}
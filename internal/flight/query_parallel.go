@@ -0,0 +1,213 @@
+package flight
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// QueryParallel is Query, but for servers that partition a result across
+// multiple Flight endpoints: rather than leaving endpoint fan-out to the
+// caller (the way the replacement-scan path hands one endpoint to each
+// DuckDB worker thread, see beginScan), it fetches every endpoint itself -
+// concurrently, bounded by concurrency - and merges the resulting streams
+// into a single array.RecordReader. Useful for callers like flight_query
+// that want one reader back rather than threading per-partition reads
+// through their own caller.
+//
+// concurrency <= 0 uses the current max_parallel_endpoints setting (see
+// SetMaxParallelEndpoints). When the server returns a single endpoint this
+// degenerates to a plain Query.
+func (c *Client) QueryParallel(ctx context.Context, sql string, concurrency int) (*QueryResult, error) {
+	return c.queryParallelWithStatement(ctx, sql, nil, concurrency)
+}
+
+// QueryParallelWithParams is QueryParallel with positional parameter
+// binding, for the same reason QueryWithParams exists alongside Query: sql
+// must already be in "$1", "$2", ... form, with one value per placeholder in
+// params.
+func (c *Client) QueryParallelWithParams(ctx context.Context, sql string, params []any, concurrency int) (*QueryResult, error) {
+	return c.queryParallelWithStatement(ctx, sql, params, concurrency)
+}
+
+func (c *Client) queryParallelWithStatement(ctx context.Context, sql string, params []any, concurrency int) (*QueryResult, error) {
+	var pq *PartitionedQuery
+	var err error
+	if len(params) > 0 {
+		pq, err = c.GetFlightInfoWithParams(ctx, sql, params)
+	} else {
+		pq, err = c.GetFlightInfo(ctx, sql)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pq.Partitions) <= 1 {
+		var partition []byte
+		if len(pq.Partitions) == 1 {
+			partition = pq.Partitions[0]
+		}
+		reader, err := c.ReadPartition(ctx, partition)
+		if err != nil {
+			pq.Stmt.Close()
+			return nil, err
+		}
+		return &QueryResult{Reader: reader, Stmt: pq.Stmt}, nil
+	}
+
+	if concurrency <= 0 {
+		concurrency = int(maxParallelEndpointsVal.Load())
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	readers, err := c.readPartitionsConcurrently(ctx, pq.Partitions, concurrency)
+	if err != nil {
+		pq.Stmt.Close()
+		return nil, err
+	}
+
+	return &QueryResult{Reader: newMergedRecordReader(pq.Schema, readers), Stmt: pq.Stmt}, nil
+}
+
+// readPartitionsConcurrently opens every partition descriptor via
+// ReadPartition, at most concurrency at a time, returning one reader per
+// partition in the same order as partitions. It's a thin adapter onto
+// partitionFetch (see partition_fetch.go): partition descriptors are opaque
+// []byte, not the string locations EndpointFetcher expects, so each is
+// carried through as a string round-trip rather than duplicating
+// partitionFetch's bounded fan-out here.
+func (c *Client) readPartitionsConcurrently(ctx context.Context, partitions [][]byte, concurrency int) ([]array.RecordReader, error) {
+	locations := make([]string, len(partitions))
+	for i, p := range partitions {
+		locations[i] = string(p)
+	}
+
+	return partitionFetch(ctx, locations, concurrency, func(ctx context.Context, location string) (array.RecordReader, error) {
+		return c.ReadPartition(ctx, []byte(location))
+	})
+}
+
+// mergedRecordReader presents several array.RecordReader sources, each
+// assumed to back a distinct Flight endpoint, as a single array.RecordReader.
+// Each source is pumped by its own goroutine as soon as the merged reader is
+// constructed, so slow endpoints don't block fast ones; Next just drains
+// whichever record becomes available first. Record order across endpoints is
+// therefore not preserved, which is fine for a query result: callers don't
+// depend on row order spanning partitions.
+type mergedRecordReader struct {
+	schema  *arrow.Schema
+	sources []array.RecordReader
+
+	cancel   context.CancelFunc
+	records  chan arrow.Record
+	errs     chan error
+	pumpDone sync.WaitGroup
+
+	cur      arrow.Record
+	err      error
+	refCount int
+	mu       sync.Mutex
+}
+
+func newMergedRecordReader(schema *arrow.Schema, sources []array.RecordReader) *mergedRecordReader {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &mergedRecordReader{
+		schema:   schema,
+		sources:  sources,
+		cancel:   cancel,
+		records:  make(chan arrow.Record, len(sources)),
+		errs:     make(chan error, len(sources)),
+		refCount: 1,
+	}
+
+	m.pumpDone.Add(len(sources))
+	for _, src := range sources {
+		go func(src array.RecordReader) {
+			defer m.pumpDone.Done()
+			for src.Next() {
+				rec := src.RecordBatch()
+				rec.Retain()
+				select {
+				case m.records <- rec:
+				case <-ctx.Done():
+					rec.Release()
+					return
+				}
+			}
+			if err := src.Err(); err != nil {
+				select {
+				case m.errs <- err:
+				default:
+				}
+			}
+		}(src)
+	}
+	go func() {
+		m.pumpDone.Wait()
+		close(m.records)
+	}()
+
+	return m
+}
+
+func (m *mergedRecordReader) Schema() *arrow.Schema { return m.schema }
+
+func (m *mergedRecordReader) Next() bool {
+	if m.cur != nil {
+		m.cur.Release()
+		m.cur = nil
+	}
+
+	rec, ok := <-m.records
+	if !ok {
+		select {
+		case err := <-m.errs:
+			m.err = err
+		default:
+		}
+		return false
+	}
+	m.cur = rec
+	return true
+}
+
+func (m *mergedRecordReader) RecordBatch() arrow.Record { return m.cur }
+
+func (m *mergedRecordReader) Err() error { return m.err }
+
+func (m *mergedRecordReader) Retain() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refCount++
+}
+
+func (m *mergedRecordReader) Release() {
+	m.mu.Lock()
+	m.refCount--
+	done := m.refCount == 0
+	m.mu.Unlock()
+	if !done {
+		return
+	}
+
+	m.cancel()
+	if m.cur != nil {
+		m.cur.Release()
+		m.cur = nil
+	}
+
+	// Wait for every pump goroutine to observe ctx.Done() and stop touching
+	// its source before releasing sources - otherwise a pump could still be
+	// mid-Next() on a source this goroutine is concurrently releasing.
+	m.pumpDone.Wait()
+	for rec := range m.records {
+		rec.Release()
+	}
+	for _, src := range m.sources {
+		src.Release()
+	}
+}
@@ -0,0 +1,128 @@
+package flight
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// fakeRecordReader is a test double for array.RecordReader backing a single
+// in-memory sequence of records, used to exercise mergedRecordReader without
+// a real Flight endpoint.
+type fakeRecordReader struct {
+	schema   *arrow.Schema
+	records  []arrow.Record
+	err      error
+	pos      int
+	released int
+	cur      arrow.Record
+}
+
+func newFakeReader(rows int, err error) *fakeRecordReader {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	var records []arrow.Record
+	for i := 0; i < rows; i++ {
+		builder.Field(0).(*array.Int64Builder).Append(int64(i))
+		records = append(records, builder.NewRecord())
+	}
+	return &fakeRecordReader{schema: schema, records: records, err: err}
+}
+
+func (f *fakeRecordReader) Schema() *arrow.Schema { return f.schema }
+
+func (f *fakeRecordReader) Next() bool {
+	if f.cur != nil {
+		f.cur.Release()
+		f.cur = nil
+	}
+	if f.pos >= len(f.records) {
+		return false
+	}
+	f.cur = f.records[f.pos]
+	f.pos++
+	return true
+}
+
+func (f *fakeRecordReader) RecordBatch() arrow.Record { return f.cur }
+func (f *fakeRecordReader) Err() error                { return f.err }
+func (f *fakeRecordReader) Retain()                   {}
+func (f *fakeRecordReader) Release()                  { f.released++ }
+
+func TestMergedRecordReaderMergesAllSources(t *testing.T) {
+	sources := []array.RecordReader{
+		newFakeReader(3, nil),
+		newFakeReader(2, nil),
+	}
+
+	merged := newMergedRecordReader(sources[0].Schema(), sources)
+
+	rows := 0
+	for merged.Next() {
+		rows += int(merged.RecordBatch().NumRows())
+	}
+	if err := merged.Err(); err != nil {
+		t.Fatalf("merged.Err() = %v, want nil", err)
+	}
+	if rows != 5 {
+		t.Errorf("got %d total rows, want 5", rows)
+	}
+
+	merged.Release()
+}
+
+func TestMergedRecordReaderPropagatesError(t *testing.T) {
+	boom := errors.New("boom")
+	sources := []array.RecordReader{
+		newFakeReader(1, boom),
+		newFakeReader(1, nil),
+	}
+
+	merged := newMergedRecordReader(sources[0].Schema(), sources)
+
+	for merged.Next() {
+	}
+	if merged.Err() == nil {
+		t.Error("merged.Err() = nil, want an error from the failing source")
+	}
+
+	merged.Release()
+}
+
+func TestMergedRecordReaderReleaseReleasesSources(t *testing.T) {
+	a := newFakeReader(1, nil)
+	b := newFakeReader(1, nil)
+	sources := []array.RecordReader{a, b}
+
+	merged := newMergedRecordReader(a.Schema(), sources)
+	for merged.Next() {
+	}
+	merged.Release()
+
+	if a.released != 1 || b.released != 1 {
+		t.Errorf("released = (%d, %d), want (1, 1)", a.released, b.released)
+	}
+}
+
+func TestMergedRecordReaderRetainDefersRelease(t *testing.T) {
+	a := newFakeReader(1, nil)
+	sources := []array.RecordReader{a}
+
+	merged := newMergedRecordReader(a.Schema(), sources)
+	merged.Retain()
+
+	merged.Release()
+	if a.released != 0 {
+		t.Errorf("source released after one of two Release() calls, want it to survive until refcount hits 0")
+	}
+
+	merged.Release()
+	if a.released != 1 {
+		t.Errorf("source released %d times after refcount reached 0, want 1", a.released)
+	}
+}
@@ -0,0 +1,164 @@
+package flight
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRetryMaxAttempts, defaultRetryBaseDelay, and defaultRetryCapDelay
+// are the out-of-the-box backoff parameters: capped exponential backoff with
+// full jitter, i.e. delay = random(0, min(cap, base*2^attempt)).
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryBaseDelay   = 50 * time.Millisecond
+	defaultRetryCapDelay    = 2 * time.Second
+)
+
+var (
+	retryEnabled     atomic.Bool
+	retryMaxAttempts atomic.Int64
+	retryBaseDelayMs atomic.Int64
+	retryCapDelayMs  atomic.Int64
+)
+
+func init() {
+	retryEnabled.Store(true)
+	retryMaxAttempts.Store(defaultRetryMaxAttempts)
+	retryBaseDelayMs.Store(defaultRetryBaseDelay.Milliseconds())
+	retryCapDelayMs.Store(defaultRetryCapDelay.Milliseconds())
+}
+
+// SetRetryEnabled toggles whether Client RPCs are retried at all.
+func SetRetryEnabled(enabled bool) { retryEnabled.Store(enabled) }
+
+// SetRetryMaxAttempts changes the maximum number of attempts (including the
+// first) made for a retryable RPC.
+func SetRetryMaxAttempts(n int) {
+	if n < 1 {
+		n = 1
+	}
+	retryMaxAttempts.Store(int64(n))
+}
+
+// SetRetryBaseDelay changes the base delay used in the exponential backoff.
+func SetRetryBaseDelay(d time.Duration) { retryBaseDelayMs.Store(d.Milliseconds()) }
+
+// SetRetryCapDelay changes the maximum delay between attempts.
+func SetRetryCapDelay(d time.Duration) { retryCapDelayMs.Store(d.Milliseconds()) }
+
+// RetryPolicy decides whether a failed call should be retried and, if so,
+// how long to wait before the next attempt.
+type RetryPolicy interface {
+	// ShouldRetry reports whether to make another call after the call with
+	// 0-based index attempt failed with err (attempt+1 calls made so far).
+	ShouldRetry(attempt int, err error) bool
+	// Backoff returns how long to wait after the failed call with 0-based
+	// index attempt, before the next call.
+	Backoff(attempt int) time.Duration
+}
+
+// DefaultRetryPolicy implements capped exponential backoff with full jitter,
+// retrying only gRPC codes that are typically transient:
+// UNAVAILABLE, DEADLINE_EXCEEDED, RESOURCE_EXHAUSTED, and INTERNAL errors
+// whose message matches a known-transient allowlist (some servers report
+// connection resets or stream aborts as INTERNAL rather than UNAVAILABLE).
+type DefaultRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	CapDelay    time.Duration
+}
+
+// currentRetryPolicy builds a DefaultRetryPolicy from the current global
+// settings. Called per-RPC so duckarrow_configure_option changes apply
+// immediately.
+func currentRetryPolicy() *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		MaxAttempts: int(retryMaxAttempts.Load()),
+		BaseDelay:   time.Duration(retryBaseDelayMs.Load()) * time.Millisecond,
+		CapDelay:    time.Duration(retryCapDelayMs.Load()) * time.Millisecond,
+	}
+}
+
+// transientInternalMarkers are substrings of INTERNAL-coded gRPC error
+// messages that real Flight SQL servers are known to use for what are
+// actually transient conditions (connection resets, stream aborts).
+var transientInternalMarkers = []string{
+	"connection reset",
+	"broken pipe",
+	"transport is closing",
+	"stream terminated",
+	"unexpected eof",
+}
+
+func (p *DefaultRetryPolicy) ShouldRetry(attempt int, err error) bool {
+	if err == nil || attempt+1 >= p.MaxAttempts {
+		return false
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	case codes.Internal:
+		msg := strings.ToLower(st.Message())
+		for _, marker := range transientInternalMarkers {
+			if strings.Contains(msg, marker) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (p *DefaultRetryPolicy) Backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	capDelay := p.CapDelay
+	if capDelay <= 0 {
+		capDelay = defaultRetryCapDelay
+	}
+
+	delay := base * time.Duration(1<<uint(attempt))
+	if delay > capDelay || delay <= 0 {
+		delay = capDelay
+	}
+
+	// Full jitter: uniform(0, delay).
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// doWithRetry runs fn, retrying per policy while ctx is still live. fn is
+// responsible for respecting ctx cancellation on its own.
+func doWithRetry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	if !retryEnabled.Load() {
+		return fn()
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || !policy.ShouldRetry(attempt, err) {
+			return err
+		}
+
+		select {
+		case <-time.After(policy.Backoff(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
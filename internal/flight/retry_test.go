@@ -0,0 +1,150 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDefaultRetryPolicyShouldRetry(t *testing.T) {
+	p := &DefaultRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, CapDelay: 10 * time.Millisecond}
+
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		want    bool
+	}{
+		{"nil error", 0, nil, false},
+		{"unavailable retried", 0, status.Error(codes.Unavailable, "down"), true},
+		{"deadline exceeded retried", 0, status.Error(codes.DeadlineExceeded, "slow"), true},
+		{"resource exhausted retried", 0, status.Error(codes.ResourceExhausted, "busy"), true},
+		{"internal transient retried", 0, status.Error(codes.Internal, "connection reset by peer"), true},
+		{"internal opaque not retried", 0, status.Error(codes.Internal, "assertion failed"), false},
+		{"permission denied not retried", 0, status.Error(codes.PermissionDenied, "nope"), false},
+		{"non-grpc error not retried", 0, errors.New("plain error"), false},
+		{"exhausted attempts not retried", 2, status.Error(codes.Unavailable, "down"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ShouldRetry(tt.attempt, tt.err); got != tt.want {
+				t.Errorf("ShouldRetry(%d, %v) = %v, want %v", tt.attempt, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyBackoffIsBounded(t *testing.T) {
+	p := &DefaultRetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, CapDelay: 100 * time.Millisecond}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		d := p.Backoff(attempt)
+		if d < 0 || d > p.CapDelay {
+			t.Errorf("Backoff(%d) = %v, want in [0, %v]", attempt, d, p.CapDelay)
+		}
+	}
+}
+
+func TestDoWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	SetRetryEnabled(true)
+	defer SetRetryEnabled(true)
+
+	policy := &DefaultRetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, CapDelay: 5 * time.Millisecond}
+
+	attempts := 0
+	err := doWithRetry(context.Background(), policy, func() error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "retry me")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("doWithRetry() error = %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoWithRetryGivesUpOnNonRetryableError(t *testing.T) {
+	policy := &DefaultRetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, CapDelay: 5 * time.Millisecond}
+
+	attempts := 0
+	wantErr := status.Error(codes.PermissionDenied, "no")
+	err := doWithRetry(context.Background(), policy, func() error {
+		attempts++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Errorf("doWithRetry() error = %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestDoWithRetryDisabled(t *testing.T) {
+	SetRetryEnabled(false)
+	defer SetRetryEnabled(true)
+
+	policy := &DefaultRetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, CapDelay: 5 * time.Millisecond}
+
+	attempts := 0
+	err := doWithRetry(context.Background(), policy, func() error {
+		attempts++
+		return status.Error(codes.Unavailable, "retry me")
+	})
+	if err == nil {
+		t.Fatal("expected error to propagate when retry is disabled")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (retry disabled)", attempts)
+	}
+}
+
+func TestDoWithRetryRespectsContextCancellation(t *testing.T) {
+	policy := &DefaultRetryPolicy{MaxAttempts: 10, BaseDelay: time.Hour, CapDelay: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := doWithRetry(ctx, policy, func() error {
+		attempts++
+		return status.Error(codes.Unavailable, "retry me")
+	})
+	if err == nil {
+		t.Fatal("expected error when context is already cancelled")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetrySettingsRoundTrip(t *testing.T) {
+	SetRetryMaxAttempts(7)
+	SetRetryBaseDelay(123 * time.Millisecond)
+	SetRetryCapDelay(456 * time.Millisecond)
+	defer func() {
+		SetRetryMaxAttempts(defaultRetryMaxAttempts)
+		SetRetryBaseDelay(defaultRetryBaseDelay)
+		SetRetryCapDelay(defaultRetryCapDelay)
+	}()
+
+	policy := currentRetryPolicy()
+	if policy.MaxAttempts != 7 {
+		t.Errorf("MaxAttempts = %d, want 7", policy.MaxAttempts)
+	}
+	if policy.BaseDelay != 123*time.Millisecond {
+		t.Errorf("BaseDelay = %v, want 123ms", policy.BaseDelay)
+	}
+	if policy.CapDelay != 456*time.Millisecond {
+		t.Errorf("CapDelay = %v, want 456ms", policy.CapDelay)
+	}
+}
@@ -0,0 +1,134 @@
+package flight
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultPinnedTxTimeout bounds how long a pinned transaction may sit with
+// no duckarrow_execute activity before it's treated as abandoned - e.g. the
+// DuckDB session that opened it crashed, was interrupted, or simply never
+// reached a matching duckarrow_commit/duckarrow_rollback.
+const defaultPinnedTxTimeout = 5 * time.Minute
+
+// pinnedTx is a ConnectionResult that duckarrow_begin has taken out of
+// autocommit mode and pinned to a DuckDB session/thread, bypassing the
+// pool's normal acquire/release cycle until duckarrow_commit or
+// duckarrow_rollback ends the transaction (or the reaper gives up on it).
+type pinnedTx struct {
+	result     *ConnectionResult
+	lastActive atomic.Int64 // unix nano
+}
+
+func newPinnedTx(result *ConnectionResult) *pinnedTx {
+	tx := &pinnedTx{result: result}
+	tx.touch()
+	return tx
+}
+
+func (tx *pinnedTx) touch() {
+	tx.lastActive.Store(time.Now().UnixNano())
+}
+
+func (tx *pinnedTx) idleFor() time.Duration {
+	return time.Since(time.Unix(0, tx.lastActive.Load()))
+}
+
+var (
+	pinnedTxMu       sync.Mutex
+	pinnedTxByThread = make(map[int]*pinnedTx)
+	pinnedTxTimeout  = defaultPinnedTxTimeout
+)
+
+// SetPinnedTransactionTimeout changes how long a pinned transaction may sit
+// idle before the pool's reaper rolls it back as abandoned.
+func SetPinnedTransactionTimeout(d time.Duration) {
+	pinnedTxMu.Lock()
+	defer pinnedTxMu.Unlock()
+	pinnedTxTimeout = d
+}
+
+// PinTransaction records result as the connection duckarrow_execute,
+// duckarrow_commit, and duckarrow_rollback should reuse for threadID,
+// instead of borrowing a fresh connection from the pool, until
+// UnpinTransaction is called for the same id. Callers are expected to have
+// already checked PinnedTransaction returns nothing for threadID - BEGIN
+// while a transaction is already open is a caller error, not something this
+// replaces silently.
+//
+// If result came from the pool, its channel is also reserved via
+// ReservePinnedConnection so Pool.Get can't hand the same channel to an
+// unrelated caller while the transaction is open - ADBC ties autocommit
+// state to the connection, not an individual statement, so anyone sharing
+// it would silently join this transaction.
+func PinTransaction(threadID int, result *ConnectionResult) {
+	if result.IsPooled {
+		ReservePinnedConnection(result.Lease)
+	}
+
+	pinnedTxMu.Lock()
+	defer pinnedTxMu.Unlock()
+	pinnedTxByThread[threadID] = newPinnedTx(result)
+}
+
+// PinnedTransaction returns the connection pinned for threadID, if any,
+// touching its last-active time so the abandoned-transaction reaper leaves
+// it alone while it's still being used.
+func PinnedTransaction(threadID int) (*ConnectionResult, bool) {
+	pinnedTxMu.Lock()
+	defer pinnedTxMu.Unlock()
+	tx, ok := pinnedTxByThread[threadID]
+	if !ok {
+		return nil, false
+	}
+	tx.touch()
+	return tx.result, true
+}
+
+// UnpinTransaction removes the pin for threadID, if any, without touching
+// the underlying connection - the caller (duckarrow_commit/duckarrow_rollback,
+// or the reaper) is responsible for committing/rolling back and
+// releasing/closing it first.
+func UnpinTransaction(threadID int) {
+	pinnedTxMu.Lock()
+	defer pinnedTxMu.Unlock()
+	delete(pinnedTxByThread, threadID)
+}
+
+// reapAbandonedTransactions rolls back and releases every pinned
+// transaction that has sat idle past pinnedTxTimeout. Invoked from the
+// pool's existing health-check loop (see Pool.runHealthCheck) so an
+// abandoned BEGIN doesn't hold a pooled connection out of autocommit mode,
+// and therefore out of circulation, forever.
+func reapAbandonedTransactions(ctx context.Context) {
+	pinnedTxMu.Lock()
+	timeout := pinnedTxTimeout
+	var stale []int
+	for threadID, tx := range pinnedTxByThread {
+		if tx.idleFor() > timeout {
+			stale = append(stale, threadID)
+		}
+	}
+	pinnedTxMu.Unlock()
+
+	for _, threadID := range stale {
+		pinnedTxMu.Lock()
+		tx, ok := pinnedTxByThread[threadID]
+		if ok {
+			delete(pinnedTxByThread, threadID)
+		}
+		pinnedTxMu.Unlock()
+		if !ok {
+			continue
+		}
+
+		tx.result.Client.Rollback(ctx)
+		if tx.result.IsPooled {
+			ReleaseConnection(tx.result.Lease)
+		} else {
+			tx.result.Client.Close()
+		}
+	}
+}
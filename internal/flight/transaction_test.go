@@ -0,0 +1,80 @@
+package flight
+
+import (
+	"testing"
+	"time"
+)
+
+// Note: exercising reapAbandonedTransactions end-to-end would require a real
+// adbc.Connection to call Rollback on, the same constraint noted in
+// prepared_cache_test.go for preparedEntry.stmt. These tests cover the
+// pinning bookkeeping itself.
+
+func TestPinnedTransactionMiss(t *testing.T) {
+	if _, ok := PinnedTransaction(123456); ok {
+		t.Error("expected miss for a thread with no pinned transaction")
+	}
+}
+
+func TestPinAndGetTransaction(t *testing.T) {
+	threadID := 1
+	result := &ConnectionResult{}
+	PinTransaction(threadID, result)
+	defer UnpinTransaction(threadID)
+
+	got, ok := PinnedTransaction(threadID)
+	if !ok {
+		t.Fatal("expected hit after PinTransaction")
+	}
+	if got != result {
+		t.Error("PinnedTransaction returned a different result than was pinned")
+	}
+}
+
+func TestUnpinTransaction(t *testing.T) {
+	threadID := 2
+	PinTransaction(threadID, &ConnectionResult{})
+
+	UnpinTransaction(threadID)
+
+	if _, ok := PinnedTransaction(threadID); ok {
+		t.Error("expected miss after UnpinTransaction")
+	}
+}
+
+func TestPinTransactionIsolatedPerThread(t *testing.T) {
+	a, b := 3, 4
+	resultA := &ConnectionResult{}
+	resultB := &ConnectionResult{}
+	PinTransaction(a, resultA)
+	PinTransaction(b, resultB)
+	defer UnpinTransaction(a)
+	defer UnpinTransaction(b)
+
+	gotA, _ := PinnedTransaction(a)
+	gotB, _ := PinnedTransaction(b)
+	if gotA != resultA || gotB != resultB {
+		t.Error("expected each thread id to keep its own pinned connection")
+	}
+}
+
+func TestPinnedTransactionTouchesLastActive(t *testing.T) {
+	threadID := 5
+	PinTransaction(threadID, &ConnectionResult{})
+	defer UnpinTransaction(threadID)
+
+	SetPinnedTransactionTimeout(time.Nanosecond)
+	defer SetPinnedTransactionTimeout(defaultPinnedTxTimeout)
+
+	time.Sleep(time.Millisecond)
+	if _, ok := PinnedTransaction(threadID); !ok {
+		t.Fatal("expected pinned transaction to still be present before the reaper runs")
+	}
+
+	pinnedTxMu.Lock()
+	idle := pinnedTxByThread[threadID].idleFor()
+	pinnedTxMu.Unlock()
+	if idle >= time.Millisecond {
+		t.Error("expected PinnedTransaction to have refreshed lastActive, leaving idleFor small")
+	}
+}
@@ -0,0 +1,149 @@
+package sqlquery
+
+import "strings"
+
+// tokenKind categorizes a token for the purposes parseRelation/parseProjection
+// care about; everything that isn't an identifier, string, or number is
+// punct (operators and the "(" / ")" / "," / "." / "*" this package inspects
+// are all punct tokens, matched on Text).
+type tokenKind int
+
+const (
+	identWord tokenKind = iota
+	identQuoted
+	stringLiteral
+	number
+	punct
+)
+
+// token is one lexical unit of a query, with its byte offsets in the
+// original source (used to slice out raw clause text) and Depth, its paren
+// nesting level - 0 at the top level, 1 inside the first "(", etc. Both the
+// opening and closing paren of a pair are recorded at the depth outside
+// them, so a top-level "(...)" is easy to spot as a single unit.
+type token struct {
+	Start, End int
+	Text       string
+	kind       tokenKind
+	Depth      int
+}
+
+// tokenize lexes a DuckDB-dialect query into tokens, handling double-quoted
+// identifiers and single-quoted strings (both with doubled-quote escapes),
+// "--" line comments, and "/* */" block comments.
+func tokenize(s string) []token {
+	var tokens []token
+	depth := 0
+	i := 0
+	n := len(s)
+
+	for i < n {
+		c := s[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '-' && i+1 < n && s[i+1] == '-':
+			for i < n && s[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < n && s[i+1] == '*':
+			end := indexFrom(s, "*/", i+2)
+			if end == -1 {
+				i = n
+			} else {
+				i = end + 2
+			}
+
+		case c == '\'':
+			end := scanQuoted(s, i, '\'')
+			tokens = append(tokens, token{Start: i, End: end, Text: s[i:end], kind: stringLiteral, Depth: depth})
+			i = end
+
+		case c == '"':
+			end := scanQuoted(s, i, '"')
+			tokens = append(tokens, token{Start: i, End: end, Text: s[i:end], kind: identQuoted, Depth: depth})
+			i = end
+
+		case c == '(':
+			tokens = append(tokens, token{Start: i, End: i + 1, Text: "(", kind: punct, Depth: depth})
+			depth++
+			i++
+
+		case c == ')':
+			depth--
+			if depth < 0 {
+				depth = 0
+			}
+			tokens = append(tokens, token{Start: i, End: i + 1, Text: ")", kind: punct, Depth: depth})
+			i++
+
+		case isWordStart(c):
+			end := i + 1
+			for end < n && isWordPart(s[end]) {
+				end++
+			}
+			tokens = append(tokens, token{Start: i, End: end, Text: s[i:end], kind: identWord, Depth: depth})
+			i = end
+
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < n && (isDigitPart(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{Start: i, End: end, Text: s[i:end], kind: number, Depth: depth})
+			i = end
+
+		default:
+			// Single-char punctuation: ",", ".", "*", "=", "<", ">", ";", etc.
+			tokens = append(tokens, token{Start: i, End: i + 1, Text: s[i : i+1], kind: punct, Depth: depth})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// scanQuoted returns the end offset (exclusive) of a quoted span starting
+// at s[start] (which must be quote), treating a doubled quote as an escaped
+// literal quote rather than the closing delimiter.
+func scanQuoted(s string, start int, quote byte) int {
+	i := start + 1
+	n := len(s)
+	for i < n {
+		if s[i] == quote {
+			if i+1 < n && s[i+1] == quote {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return n
+}
+
+func indexFrom(s, substr string, start int) int {
+	if start > len(s) {
+		return -1
+	}
+	idx := strings.Index(s[start:], substr)
+	if idx == -1 {
+		return -1
+	}
+	return start + idx
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isWordPart(c byte) bool {
+	return isWordStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
+
+func isDigitPart(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E'
+}
@@ -0,0 +1,276 @@
+// Package sqlquery provides a small, purpose-built parser for the subset of
+// DuckDB's SELECT dialect the main package's query builders need to
+// understand: which relation (if any) a query scans, its existing
+// projection, and its WHERE/GROUP BY/ORDER BY/LIMIT clauses. It's
+// deliberately not a general SQL parser - anything beyond a single-table
+// scan (joins, CTEs, subqueries in FROM, set operations) is reported as
+// such rather than rejected, so callers can fall back to treating the whole
+// query as an opaque subquery.
+//
+// This is separated from the main package to enable unit testing without
+// CGO, matching internal/validation.
+package sqlquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Relation identifies the table a simple query scans, as it appeared in the
+// query's FROM clause. Catalog and Schema are empty when the query didn't
+// qualify the table name.
+type Relation struct {
+	Catalog string
+	Schema  string
+	Table   string
+}
+
+// QualifiedName re-quotes r for use in a FROM clause, quoting only the parts
+// that were present.
+func (r Relation) QualifiedName() string {
+	parts := make([]string, 0, 3)
+	if r.Catalog != "" {
+		parts = append(parts, quoteIdent(r.Catalog))
+	}
+	if r.Schema != "" {
+		parts = append(parts, quoteIdent(r.Schema))
+	}
+	parts = append(parts, quoteIdent(r.Table))
+	return strings.Join(parts, ".")
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// Query is the structural result of parsing a SELECT statement. Relation is
+// nil when the query isn't a simple single-table scan (a join, a CTE, a
+// subquery in FROM, a set operation, ...); callers should fall back to
+// treating Original as an opaque subquery in that case. Projection is nil
+// when the query selects "*". Where, GroupBy, OrderBy, and Limit hold the
+// clause's raw text (as written, excluding the keyword itself), or "" if
+// the query didn't have one.
+type Query struct {
+	Original   string
+	Relation   *Relation
+	Projection []string
+	Where      string
+	GroupBy    string
+	OrderBy    string
+	Limit      string
+}
+
+// Parse parses query as far as the simple-table-scan subset goes. It only
+// returns an error for input that isn't a query at all (empty text);
+// anything it can't confidently parse - a non-SELECT statement, a join, a
+// CTE - comes back as a *Query with Relation left nil, not an error, so
+// callers can fall back to wrapping Original as a subquery.
+func Parse(query string) (*Query, error) {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	core := strings.TrimRight(strings.TrimSuffix(trimmed, ";"), " \t\n\r")
+	q := &Query{Original: core}
+
+	tokens := tokenize(core)
+	if len(tokens) == 0 || !isKeyword(tokens[0].Text, "SELECT") {
+		return q, nil
+	}
+	if hasTopLevelKeyword(tokens, "UNION") || hasTopLevelKeyword(tokens, "INTERSECT") || hasTopLevelKeyword(tokens, "EXCEPT") {
+		return q, nil
+	}
+
+	fromIdx := findTopLevelKeyword(tokens, 1, "FROM")
+	if fromIdx == -1 {
+		return q, nil
+	}
+	q.Projection = parseProjection(tokens[1:fromIdx], core)
+
+	fromEnd := findTopLevelKeywordAny(tokens, fromIdx+1, "WHERE", "GROUP", "ORDER", "LIMIT")
+	if fromEnd == -1 {
+		fromEnd = len(tokens)
+	}
+	q.Relation = parseRelation(tokens[fromIdx+1 : fromEnd])
+
+	idx := fromEnd
+	if idx < len(tokens) && isKeyword(tokens[idx].Text, "WHERE") {
+		end := findTopLevelKeywordAny(tokens, idx+1, "GROUP", "ORDER", "LIMIT")
+		if end == -1 {
+			end = len(tokens)
+		}
+		q.Where = clauseText(core, tokens, idx+1, end)
+		idx = end
+	}
+
+	if idx < len(tokens) && isKeyword(tokens[idx].Text, "GROUP") {
+		start := idx + 1
+		if start < len(tokens) && isKeyword(tokens[start].Text, "BY") {
+			start++
+		}
+		end := findTopLevelKeywordAny(tokens, start, "ORDER", "LIMIT")
+		if end == -1 {
+			end = len(tokens)
+		}
+		q.GroupBy = clauseText(core, tokens, start, end)
+		idx = end
+	}
+
+	if idx < len(tokens) && isKeyword(tokens[idx].Text, "ORDER") {
+		start := idx + 1
+		if start < len(tokens) && isKeyword(tokens[start].Text, "BY") {
+			start++
+		}
+		end := findTopLevelKeywordAny(tokens, start, "LIMIT")
+		if end == -1 {
+			end = len(tokens)
+		}
+		q.OrderBy = clauseText(core, tokens, start, end)
+		idx = end
+	}
+
+	if idx < len(tokens) && isKeyword(tokens[idx].Text, "LIMIT") {
+		q.Limit = clauseText(core, tokens, idx+1, len(tokens))
+	}
+
+	return q, nil
+}
+
+// clauseText slices core for the raw text spanned by tokens[start:end],
+// trimmed, or "" if the span is empty.
+func clauseText(core string, tokens []token, start, end int) string {
+	if start >= end {
+		return ""
+	}
+	return strings.TrimSpace(core[tokens[start].Start:tokens[end-1].End])
+}
+
+// parseProjection reads the SELECT list's tokens. Returns nil for "SELECT
+// *"; otherwise the raw (un-parsed) text of each comma-separated item.
+func parseProjection(tokens []token, core string) []string {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if len(tokens) == 1 && tokens[0].Text == "*" {
+		return nil
+	}
+
+	var items []string
+	start := 0
+	for i, t := range tokens {
+		if t.Depth == 0 && t.Text == "," {
+			items = append(items, clauseText(core, tokens, start, i))
+			start = i + 1
+		}
+	}
+	items = append(items, clauseText(core, tokens, start, len(tokens)))
+	return items
+}
+
+// parseRelation reads the FROM clause's tokens (everything between FROM and
+// the next top-level WHERE/GROUP BY/ORDER BY/LIMIT). Returns nil if this
+// isn't a single, directly named table - a subquery, a join, multiple
+// comma-separated tables, or anything else this parser doesn't model.
+func parseRelation(tokens []token) *Relation {
+	if len(tokens) == 0 || tokens[0].Text == "(" {
+		return nil
+	}
+
+	for _, t := range tokens {
+		if t.Depth != 0 {
+			continue
+		}
+		if t.Text == "," {
+			return nil
+		}
+		switch strings.ToUpper(t.Text) {
+		case "JOIN", "LEFT", "RIGHT", "INNER", "OUTER", "FULL", "CROSS":
+			return nil
+		}
+	}
+
+	i := 0
+	var parts []string
+	for i < len(tokens) && isIdentToken(tokens[i]) {
+		parts = append(parts, identText(tokens[i]))
+		i++
+		if i < len(tokens) && tokens[i].Text == "." {
+			i++
+			continue
+		}
+		break
+	}
+	if len(parts) == 0 || len(parts) > 3 {
+		return nil
+	}
+
+	rest := tokens[i:]
+	if len(rest) > 0 {
+		if isKeyword(rest[0].Text, "AS") {
+			rest = rest[1:]
+		}
+		if len(rest) != 1 || !isIdentToken(rest[0]) {
+			return nil
+		}
+	}
+
+	rel := &Relation{}
+	switch len(parts) {
+	case 1:
+		rel.Table = parts[0]
+	case 2:
+		rel.Schema, rel.Table = parts[0], parts[1]
+	case 3:
+		rel.Catalog, rel.Schema, rel.Table = parts[0], parts[1], parts[2]
+	}
+	return rel
+}
+
+// isIdentToken reports whether t can be part of a dotted relation path: a
+// quoted identifier or an unquoted word.
+func isIdentToken(t token) bool {
+	return t.kind == identWord || t.kind == identQuoted
+}
+
+// identText unescapes and case-folds an identifier token per DuckDB rules:
+// quoted identifiers keep their case and unescape doubled quotes; unquoted
+// identifiers fold to lowercase.
+func identText(t token) string {
+	if t.kind == identQuoted {
+		inner := t.Text[1 : len(t.Text)-1]
+		return strings.ReplaceAll(inner, `""`, `"`)
+	}
+	return strings.ToLower(t.Text)
+}
+
+func isKeyword(text, keyword string) bool {
+	return strings.EqualFold(text, keyword)
+}
+
+func findTopLevelKeyword(tokens []token, start int, keyword string) int {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Depth == 0 && isKeyword(tokens[i].Text, keyword) {
+			return i
+		}
+	}
+	return -1
+}
+
+func findTopLevelKeywordAny(tokens []token, start int, keywords ...string) int {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Depth != 0 {
+			continue
+		}
+		for _, kw := range keywords {
+			if isKeyword(tokens[i].Text, kw) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func hasTopLevelKeyword(tokens []token, keyword string) bool {
+	return findTopLevelKeyword(tokens, 0, keyword) != -1
+}
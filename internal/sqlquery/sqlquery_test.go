@@ -0,0 +1,155 @@
+package sqlquery
+
+import "testing"
+
+func TestParseEmptyQuery(t *testing.T) {
+	if _, err := Parse(""); err == nil {
+		t.Error("Parse(\"\") expected an error")
+	}
+	if _, err := Parse("   "); err == nil {
+		t.Error("Parse(whitespace) expected an error")
+	}
+}
+
+func TestParseSimpleTableScan(t *testing.T) {
+	q, err := Parse(`SELECT * FROM "Order"`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if q.Relation == nil || q.Relation.Table != "Order" {
+		t.Fatalf("Relation = %+v, want Table Order", q.Relation)
+	}
+	if q.Projection != nil {
+		t.Errorf("Projection = %v, want nil for SELECT *", q.Projection)
+	}
+}
+
+func TestParseQuotedIdentifierEscaping(t *testing.T) {
+	q, err := Parse(`SELECT * FROM "table""name"`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if q.Relation == nil || q.Relation.Table != `table"name` {
+		t.Fatalf("Relation = %+v, want Table table\"name", q.Relation)
+	}
+}
+
+func TestParseUnquotedIdentifierFoldsLowercase(t *testing.T) {
+	q, err := Parse(`SELECT * FROM Customers`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if q.Relation == nil || q.Relation.Table != "customers" {
+		t.Fatalf("Relation = %+v, want Table customers", q.Relation)
+	}
+}
+
+func TestParseSchemaAndCatalogQualified(t *testing.T) {
+	q, err := Parse(`SELECT * FROM "db"."sch"."Order"`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := Relation{Catalog: "db", Schema: "sch", Table: "Order"}
+	if q.Relation == nil || *q.Relation != want {
+		t.Fatalf("Relation = %+v, want %+v", q.Relation, want)
+	}
+}
+
+func TestParseTableAliasIgnored(t *testing.T) {
+	for _, query := range []string{
+		`SELECT * FROM "Order" AS o`,
+		`SELECT * FROM "Order" o`,
+	} {
+		q, err := Parse(query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", query, err)
+		}
+		if q.Relation == nil || q.Relation.Table != "Order" {
+			t.Errorf("Parse(%q) Relation = %+v, want Table Order", query, q.Relation)
+		}
+	}
+}
+
+func TestParseProjectionList(t *testing.T) {
+	q, err := Parse(`SELECT "id", "name" FROM "Order"`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []string{`"id"`, `"name"`}
+	if len(q.Projection) != len(want) || q.Projection[0] != want[0] || q.Projection[1] != want[1] {
+		t.Errorf("Projection = %v, want %v", q.Projection, want)
+	}
+}
+
+func TestParseWhereGroupByOrderByLimit(t *testing.T) {
+	q, err := Parse(`SELECT * FROM "Order" WHERE "status" = 'shipped' GROUP BY "region" ORDER BY "id" DESC LIMIT 10`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if q.Where != `"status" = 'shipped'` {
+		t.Errorf("Where = %q", q.Where)
+	}
+	if q.GroupBy != `"region"` {
+		t.Errorf("GroupBy = %q", q.GroupBy)
+	}
+	if q.OrderBy != `"id" DESC` {
+		t.Errorf("OrderBy = %q", q.OrderBy)
+	}
+	if q.Limit != "10" {
+		t.Errorf("Limit = %q", q.Limit)
+	}
+}
+
+func TestParseWhereClauseWithNestedParens(t *testing.T) {
+	q, err := Parse(`SELECT * FROM "Order" WHERE ("status" = 'shipped' OR "status" = 'pending') AND "id" > 5`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := `("status" = 'shipped' OR "status" = 'pending') AND "id" > 5`
+	if q.Where != want {
+		t.Errorf("Where = %q, want %q", q.Where, want)
+	}
+}
+
+func TestParseNonSimpleQueriesHaveNilRelation(t *testing.T) {
+	tests := []string{
+		`SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id"`,
+		`SELECT * FROM "a", "b"`,
+		`SELECT * FROM (SELECT * FROM "a") AS t`,
+		`WITH t AS (SELECT * FROM "a") SELECT * FROM t`,
+		`SELECT * FROM "a" UNION SELECT * FROM "b"`,
+		`INSERT INTO "a" VALUES (1)`,
+	}
+	for _, query := range tests {
+		q, err := Parse(query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", query, err)
+		}
+		if q.Relation != nil {
+			t.Errorf("Parse(%q) Relation = %+v, want nil", query, q.Relation)
+		}
+		if q.Original == "" {
+			t.Errorf("Parse(%q) Original is empty, want original query preserved", query)
+		}
+	}
+}
+
+func TestQualifiedName(t *testing.T) {
+	tests := []struct {
+		name string
+		rel  Relation
+		want string
+	}{
+		{"table only", Relation{Table: "Order"}, `"Order"`},
+		{"schema and table", Relation{Schema: "s", Table: "Order"}, `"s"."Order"`},
+		{"catalog schema table", Relation{Catalog: "db", Schema: "s", Table: "Order"}, `"db"."s"."Order"`},
+		{"table with embedded quote", Relation{Table: `My"Table`}, `"My""Table"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rel.QualifiedName(); got != tt.want {
+				t.Errorf("QualifiedName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
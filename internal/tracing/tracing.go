@@ -0,0 +1,212 @@
+// Package tracing provides an optional OpenTelemetry integration so a
+// duckarrow query can be correlated with the downstream Flight SQL RPC it
+// triggers. It is kept separate from the flight and main packages so both
+// can open spans without creating an import cycle, and so tracing stays
+// strictly additive: with it disabled (the default), StartSpan is a cheap
+// no-op and nothing else in the extension needs to branch on whether a
+// TracerProvider was ever configured.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tracerName identifies duckarrow's spans among others a collector may
+// receive from the rest of the pipeline.
+const tracerName = "github.com/JC1738/duckarrow"
+
+var (
+	enabled atomic.Bool
+
+	providerMu       sync.Mutex
+	providerShutdown func(context.Context) error
+
+	propagator = propagation.TraceContext{}
+)
+
+func init() {
+	otel.SetTextMapPropagator(propagator)
+}
+
+// SetEnabled toggles whether duckarrow emits spans at all. Configure can be
+// called independently of this; spans are only ever opened while enabled.
+func SetEnabled(v bool) { enabled.Store(v) }
+
+// Enabled reports whether tracing is currently turned on.
+func Enabled() bool { return enabled.Load() }
+
+// Configure points duckarrow's tracer at an OTLP/gRPC collector endpoint
+// (e.g. "localhost:4317"), replacing and shutting down any previously
+// configured provider. It does not itself enable tracing; pair it with
+// SetEnabled(true) (or duckarrow_configure_option('otel_enabled', 'true')).
+func Configure(ctx context.Context, endpoint string) error {
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return fmt.Errorf("create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if providerShutdown != nil {
+		_ = providerShutdown(ctx)
+	}
+	otel.SetTracerProvider(tp)
+	providerShutdown = tp.Shutdown
+	return nil
+}
+
+// Shutdown flushes and releases the current tracer provider, if any. Called
+// at extension unload so the batch exporter's background goroutine doesn't
+// leak past the lifetime of the DuckDB connection that configured it.
+func Shutdown(ctx context.Context) error {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if providerShutdown == nil {
+		return nil
+	}
+	err := providerShutdown(ctx)
+	providerShutdown = nil
+	return err
+}
+
+// StartSpan starts a span named name when tracing is enabled; otherwise it
+// returns ctx unchanged along with a no-op span. Callers can unconditionally
+// `defer span.End()` (or defer tracing.EndWithError(span, &err)) without
+// branching on whether tracing is active.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndWithError records err on span (if non-nil), sets the span status
+// accordingly, and ends it. It's meant to be deferred right after StartSpan
+// so the span closes on every return path of the CGO callback it guards,
+// including the early-return error paths that run before the C allocation
+// is handed back to DuckDB.
+func EndWithError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if st, ok := status.FromError(err); ok {
+			span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
+		}
+	}
+	span.End()
+}
+
+// traceParentByThread holds the most recently injected W3C traceparent per
+// OS thread, set via duckarrow_set_trace_context so a SQL session can
+// stitch its own trace to the Flight RPCs duckarrow issues on its behalf.
+// CGO callbacks have no ambient context to thread a value through, so this
+// is keyed the same way transaction.go's pinnedTxByThread is in the flight
+// package: DuckDB invokes a given scalar function consistently from the
+// same worker thread for the life of a session, and every call site here
+// already holds runtime.LockOSThread() (required for CGO callbacks anyway),
+// so the calling goroutine's OS thread ID is a stable key for "this DuckDB
+// session". A single process-global value would instead let one session's
+// duckarrow_set_trace_context clobber the trace parent every other
+// concurrently-running session's Flight spans get attached to.
+var (
+	traceParentMu       sync.Mutex
+	traceParentByThread = make(map[int]string)
+)
+
+// currentThreadID identifies the OS thread the calling goroutine is pinned
+// to, the same way transaction_functions.go's currentThreadID does for
+// pinnedTxByThread. It's duplicated here rather than imported because
+// package main imports tracing, so tracing importing main back would be a
+// cycle.
+//
+// Linux-only: syscall.Gettid has no equivalent in the syscall package on
+// other platforms.
+func currentThreadID() int {
+	return syscall.Gettid()
+}
+
+// SetTraceParent stores the active traceparent header for the calling OS
+// thread, for subsequent calls to ContextWithTraceParent from the same
+// thread. An empty string clears it.
+func SetTraceParent(traceparent string) {
+	threadID := currentThreadID()
+	traceParentMu.Lock()
+	defer traceParentMu.Unlock()
+	if traceparent == "" {
+		delete(traceParentByThread, threadID)
+		return
+	}
+	traceParentByThread[threadID] = traceparent
+}
+
+// ContextWithTraceParent returns ctx extended with the remote span context
+// carried by the calling OS thread's most recently set traceparent (if
+// any), so spans opened from the returned context become children of the
+// caller's trace.
+func ContextWithTraceParent(ctx context.Context) context.Context {
+	threadID := currentThreadID()
+	traceParentMu.Lock()
+	v := traceParentByThread[threadID]
+	traceParentMu.Unlock()
+	if v == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{"traceparent": v})
+}
+
+// UnaryClientInterceptor injects the active span context from ctx into
+// outgoing gRPC metadata using the standard W3C trace-context propagator,
+// so the Flight SQL server's own tracing (if any) can be correlated with
+// ours.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(injectMetadata(ctx), method, req, reply, cc, opts...)
+}
+
+// StreamClientInterceptor is the streaming-RPC counterpart of
+// UnaryClientInterceptor. DoGet/DoPut are client streams, so both
+// interceptors must be installed for every Flight call to carry trace
+// context.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(injectMetadata(ctx), desc, cc, method, opts...)
+}
+
+// injectMetadata writes the W3C trace-context carried by ctx into the
+// outgoing gRPC metadata, preserving whatever metadata is already present.
+func injectMetadata(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	for k, v := range carrier {
+		md.Set(k, v)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestStartSpanNoopWhenDisabled(t *testing.T) {
+	SetEnabled(false)
+
+	ctx, span := StartSpan(context.Background(), "duckarrow.test")
+	if ctx != context.Background() {
+		t.Error("StartSpan() should return ctx unchanged when tracing is disabled")
+	}
+	if span.SpanContext().IsValid() {
+		t.Error("StartSpan() should return a no-op span when tracing is disabled")
+	}
+	// Must be safe to call even though no real span was opened.
+	EndWithError(span, nil)
+}
+
+func TestEnabledRoundTrip(t *testing.T) {
+	SetEnabled(true)
+	defer SetEnabled(false)
+
+	if !Enabled() {
+		t.Error("Enabled() = false after SetEnabled(true)")
+	}
+}
+
+func TestSetTraceParentRoundTrip(t *testing.T) {
+	defer SetTraceParent("")
+
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	SetTraceParent(tp)
+
+	ctx := ContextWithTraceParent(context.Background())
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		t.Fatal("ContextWithTraceParent() did not extract a valid span context")
+	}
+	if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceID = %s, want 4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID())
+	}
+}
+
+func TestContextWithTraceParentEmptyIsNoop(t *testing.T) {
+	SetTraceParent("")
+	ctx := context.Background()
+	if got := ContextWithTraceParent(ctx); got != ctx {
+		t.Error("ContextWithTraceParent() should return ctx unchanged when no traceparent is set")
+	}
+}
+
+func TestInjectMetadataNoSpanIsNoop(t *testing.T) {
+	ctx := injectMetadata(context.Background())
+	if _, ok := metadata.FromOutgoingContext(ctx); ok {
+		t.Error("injectMetadata() should not add metadata when ctx carries no valid span context")
+	}
+}
+
+func TestInjectMetadataWritesTraceparent(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	ctx = injectMetadata(ctx)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		t.Fatal("injectMetadata() did not attach outgoing metadata")
+	}
+	if got := md.Get("traceparent"); len(got) != 1 || got[0] == "" {
+		t.Errorf("traceparent header = %v, want a non-empty single value", got)
+	}
+}
+
+func TestUnaryClientInterceptorInvokesInvoker(t *testing.T) {
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		return nil
+	}
+
+	err := UnaryClientInterceptor(context.Background(), "/test/Method", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("UnaryClientInterceptor() error = %v", err)
+	}
+	if !called {
+		t.Error("UnaryClientInterceptor() did not call the wrapped invoker")
+	}
+}
@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultProfile selects which dialect's built-in skip rules a SkipPolicy
+// composes. The remote behind a duckarrow endpoint is most often DuckDB
+// itself, which can have Postgres or SQLite databases ATTACHed or be
+// fronting MotherDuck, so ProfileDuckDB (the default) folds in all of their
+// internal-table conventions rather than just DuckDB's own.
+type DefaultProfile string
+
+const (
+	ProfileDuckDB     DefaultProfile = "duckdb"
+	ProfilePostgres   DefaultProfile = "postgres"
+	ProfileSQLite     DefaultProfile = "sqlite"
+	ProfileMotherDuck DefaultProfile = "motherduck"
+	ProfileNone       DefaultProfile = "none"
+)
+
+// SkipPolicy determines which remote table names ShouldSkipTableWith treats
+// as internal/system tables rather than user data. DefaultProfile supplies a
+// base set of rules for a known dialect; ExactNames, Prefixes, Suffixes, and
+// Regexes let an operator layer their own conventions (e.g. "dbt_*_tmp",
+// "flyway_schema_history") on top. A name is skipped if it matches either
+// the profile's built-in rules or any of the policy's own.
+type SkipPolicy struct {
+	DefaultProfile DefaultProfile
+	ExactNames     []string
+	Prefixes       []string
+	Suffixes       []string
+	Regexes        []*regexp.Regexp
+}
+
+// skipRules is the plain rule set backing a DefaultProfile; it's the same
+// shape as the user-supplied fields of SkipPolicy so both can be tested with
+// matchesRules.
+type skipRules struct {
+	exactNames []string
+	prefixes   []string
+	suffixes   []string
+}
+
+func builtinRules(profile DefaultProfile) skipRules {
+	switch profile {
+	case ProfilePostgres:
+		return skipRules{
+			exactNames: []string{"information_schema"},
+			prefixes:   []string{"pg_"},
+		}
+	case ProfileSQLite:
+		return skipRules{prefixes: []string{"sqlite_"}}
+	case ProfileMotherDuck:
+		return skipRules{prefixes: []string{"mdclientcache_"}}
+	case ProfileNone:
+		return skipRules{}
+	case ProfileDuckDB, "":
+		return skipRules{
+			exactNames: []string{"information_schema"},
+			prefixes:   []string{"pg_", "sqlite_", "__duckdb_internal", "__duckarrow", "mdclientcache_"},
+		}
+	default:
+		return skipRules{}
+	}
+}
+
+// ShouldSkipTableWith reports whether name should be hidden from the tables
+// duckarrow exposes, per policy. A nil policy falls back to
+// DefaultProfile "duckdb"'s built-in rules with no user additions.
+func ShouldSkipTableWith(name string, policy *SkipPolicy) bool {
+	if policy == nil {
+		policy = &SkipPolicy{DefaultProfile: ProfileDuckDB}
+	}
+
+	builtin := builtinRules(policy.DefaultProfile)
+	if matchesRules(name, builtin.exactNames, builtin.prefixes, builtin.suffixes, nil) {
+		return true
+	}
+	return matchesRules(name, policy.ExactNames, policy.Prefixes, policy.Suffixes, policy.Regexes)
+}
+
+// matchesRules reports whether name matches any of exactNames, prefixes, or
+// suffixes (all case-insensitive, since remote table names may not follow
+// DuckDB's own lowercase-folding convention), or any of regexes (matched
+// as-is, since a regex can already express case-insensitivity with (?i)).
+func matchesRules(name string, exactNames, prefixes, suffixes []string, regexes []*regexp.Regexp) bool {
+	lower := strings.ToLower(name)
+
+	for _, exact := range exactNames {
+		if lower == strings.ToLower(exact) {
+			return true
+		}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(lower, strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	for _, re := range regexes {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	defaultSkipPolicyMu sync.RWMutex
+	defaultSkipPolicy   = &SkipPolicy{DefaultProfile: ProfileDuckDB}
+)
+
+// DefaultSkipPolicy returns the policy ShouldSkipTable currently consults.
+// Callers that want to layer an additional rule onto it should build a new
+// SkipPolicy from the result and pass it to SetDefaultSkipPolicy rather than
+// mutating the returned value in place.
+func DefaultSkipPolicy() *SkipPolicy {
+	defaultSkipPolicyMu.RLock()
+	defer defaultSkipPolicyMu.RUnlock()
+	return defaultSkipPolicy
+}
+
+// SetDefaultSkipPolicy replaces the policy ShouldSkipTable consults. A nil
+// policy resets it to the out-of-the-box default (DefaultProfile "duckdb",
+// no user additions).
+func SetDefaultSkipPolicy(policy *SkipPolicy) {
+	if policy == nil {
+		policy = &SkipPolicy{DefaultProfile: ProfileDuckDB}
+	}
+	defaultSkipPolicyMu.Lock()
+	defer defaultSkipPolicyMu.Unlock()
+	defaultSkipPolicy = policy
+}
+
+// ShouldSkipTable reports whether name looks like a DuckDB/Postgres/SQLite
+// internal table, a MotherDuck metadata cache table, or matches a pattern an
+// operator has added via SetDefaultSkipPolicy, rather than user data that
+// should be exposed through duckarrow.
+func ShouldSkipTable(name string) bool {
+	defaultSkipPolicyMu.RLock()
+	policy := defaultSkipPolicy
+	defaultSkipPolicyMu.RUnlock()
+	return ShouldSkipTableWith(name, policy)
+}
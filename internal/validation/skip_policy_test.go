@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestShouldSkipTableWithNilPolicyMatchesDuckDBProfile(t *testing.T) {
+	if !ShouldSkipTableWith("pg_catalog", nil) {
+		t.Error("nil policy should fall back to the duckdb default profile")
+	}
+	if ShouldSkipTableWith("users", nil) {
+		t.Error("nil policy should not skip regular tables")
+	}
+}
+
+func TestShouldSkipTableWithProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile DefaultProfile
+		table   string
+		want    bool
+	}{
+		{"postgres profile matches pg_", ProfilePostgres, "pg_type", true},
+		{"postgres profile ignores sqlite", ProfilePostgres, "sqlite_master", false},
+		{"sqlite profile matches sqlite_", ProfileSQLite, "sqlite_sequence", true},
+		{"sqlite profile ignores pg_", ProfileSQLite, "pg_catalog", false},
+		{"motherduck profile matches mdclientcache_", ProfileMotherDuck, "mdClientCache_abc", true},
+		{"none profile skips nothing built-in", ProfileNone, "pg_catalog", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ShouldSkipTableWith(tt.table, &SkipPolicy{DefaultProfile: tt.profile})
+			if got != tt.want {
+				t.Errorf("ShouldSkipTableWith(%q, profile=%s) = %v, want %v", tt.table, tt.profile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldSkipTableWithUserRules(t *testing.T) {
+	policy := &SkipPolicy{
+		DefaultProfile: ProfileNone,
+		ExactNames:     []string{"flyway_schema_history"},
+		Prefixes:       []string{"_airbyte_raw_"},
+		Suffixes:       []string{"_tmp"},
+		Regexes:        []*regexp.Regexp{regexp.MustCompile(`^dbt_.*_tmp$`)},
+	}
+
+	for _, tt := range []struct {
+		table string
+		want  bool
+	}{
+		{"flyway_schema_history", true},
+		{"_airbyte_raw_orders", true},
+		{"stage_tmp", true},
+		{"dbt_model_tmp", true},
+		{"users", false},
+	} {
+		if got := ShouldSkipTableWith(tt.table, policy); got != tt.want {
+			t.Errorf("ShouldSkipTableWith(%q) = %v, want %v", tt.table, got, tt.want)
+		}
+	}
+}
+
+func TestShouldSkipTableWithUserRulesLayerOnProfile(t *testing.T) {
+	policy := &SkipPolicy{
+		DefaultProfile: ProfilePostgres,
+		Prefixes:       []string{"_airbyte_raw_"},
+	}
+
+	if !ShouldSkipTableWith("pg_type", policy) {
+		t.Error("postgres profile's own rules should still apply")
+	}
+	if !ShouldSkipTableWith("_airbyte_raw_orders", policy) {
+		t.Error("user-supplied prefix should apply alongside the profile")
+	}
+}
+
+func TestSetDefaultSkipPolicyOptsOutOfMotherDuckSkipping(t *testing.T) {
+	t.Cleanup(func() { SetDefaultSkipPolicy(nil) })
+
+	SetDefaultSkipPolicy(&SkipPolicy{DefaultProfile: ProfilePostgres})
+	if ShouldSkipTable("mdclientcache_abc") {
+		t.Error("opting into the postgres profile should stop skipping MotherDuck cache tables")
+	}
+	if !ShouldSkipTable("pg_catalog") {
+		t.Error("postgres profile should still skip pg_ tables")
+	}
+
+	SetDefaultSkipPolicy(nil)
+	if !ShouldSkipTable("mdclientcache_abc") {
+		t.Error("resetting to nil should restore the duckdb default profile")
+	}
+}
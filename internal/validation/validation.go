@@ -4,6 +4,7 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 )
@@ -38,48 +39,105 @@ func ValidateTableName(name string) error {
 	return nil
 }
 
-// ValidateURI performs validation on the gRPC URI.
+// ParsedURI is the structural result of parsing a duckarrow endpoint URI.
+// Host/Port are populated for the TCP schemes (grpc, grpc+tls); Path holds
+// the socket path for grpc+unix. Username/Password come from the URI's
+// userinfo, if present, so the client layer can forward them as a
+// bearer/basic credential. Query carries endpoint options such as
+// tls_skip_verify or ca.
+type ParsedURI struct {
+	Scheme   string
+	Host     string // hostname only, e.g. "fe80::1%eth0" (zone id preserved, no brackets/port)
+	Port     string
+	Username string
+	Password string
+	Path     string // unix socket path, grpc+unix only
+	Query    url.Values
+}
+
+// ParseURI parses and structurally validates a duckarrow endpoint URI.
 // It checks for:
-// - Non-empty URI
-// - Valid grpc:// or grpc+tls:// scheme
-// - Presence of host component
-// - Reasonable length limit
-func ValidateURI(uri string) error {
+//   - Non-empty URI within a reasonable length limit
+//   - A supported scheme: grpc, grpc+tls, or grpc+unix (a local Unix domain
+//     socket path carried in the URL path)
+//   - A non-empty host for the TCP schemes, round-tripping through
+//     net.SplitHostPort when a port is present (this also validates IPv6
+//     literals, including zone ids like "[fe80::1%eth0]")
+//   - A non-empty path for grpc+unix
+//
+// Userinfo (e.g. "user:token@host") and query parameters (e.g.
+// "?tls_skip_verify=true&ca=/etc/ca.pem") are surfaced on the returned
+// ParsedURI rather than rejected.
+func ParseURI(uri string) (*ParsedURI, error) {
 	uri = strings.TrimSpace(uri)
 	if uri == "" {
-		return fmt.Errorf("URI cannot be empty")
+		return nil, fmt.Errorf("URI cannot be empty")
 	}
 
 	// Check length limit to prevent abuse
 	if len(uri) > 2048 {
-		return fmt.Errorf("URI exceeds maximum length of 2048 characters")
+		return nil, fmt.Errorf("URI exceeds maximum length of 2048 characters")
 	}
 
-	// Check for valid scheme prefix
-	var hostPart string
-	if strings.HasPrefix(uri, "grpc+tls://") {
-		hostPart = strings.TrimPrefix(uri, "grpc+tls://")
-	} else if strings.HasPrefix(uri, "grpc://") {
-		hostPart = strings.TrimPrefix(uri, "grpc://")
-	} else {
-		return fmt.Errorf("URI must start with grpc:// or grpc+tls://")
+	u, err := url.Parse(escapeIPv6Zone(uri))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URI format: %v", err)
 	}
 
-	// Check that host is present
-	if hostPart == "" {
-		return fmt.Errorf("URI must include a host")
+	switch u.Scheme {
+	case "grpc", "grpc+tls":
+		if u.Host == "" {
+			return nil, fmt.Errorf("URI must include a host")
+		}
+		if u.Port() != "" {
+			if _, _, err := net.SplitHostPort(u.Host); err != nil {
+				return nil, fmt.Errorf("invalid host:port: %v", err)
+			}
+		}
+
+		parsed := &ParsedURI{Scheme: u.Scheme, Host: u.Hostname(), Port: u.Port(), Query: u.Query()}
+		if u.User != nil {
+			parsed.Username = u.User.Username()
+			parsed.Password, _ = u.User.Password()
+		}
+		return parsed, nil
+
+	case "grpc+unix":
+		if u.Path == "" {
+			return nil, fmt.Errorf("URI must include a socket path")
+		}
+		return &ParsedURI{Scheme: u.Scheme, Path: u.Path, Query: u.Query()}, nil
+
+	default:
+		return nil, fmt.Errorf("URI must start with grpc://, grpc+tls://, or grpc+unix://")
 	}
+}
 
-	// Use net/url to validate the host:port format
-	// We prepend "http://" temporarily since url.Parse requires a known scheme
-	testURL, err := url.Parse("http://" + hostPart)
-	if err != nil {
-		return fmt.Errorf("invalid URI format: %v", err)
+// escapeIPv6Zone rewrites a bracketed IPv6 literal's zone id delimiter from a
+// bare "%" to the RFC 6874 percent-escaped "%25" (e.g. "[fe80::1%eth0]" ->
+// "[fe80::1%25eth0]") so net/url.Parse, which requires the escaped form,
+// accepts it. Left alone if there's no bracketed host or the zone id is
+// already escaped.
+func escapeIPv6Zone(uri string) string {
+	start := strings.IndexByte(uri, '[')
+	end := strings.IndexByte(uri, ']')
+	if start == -1 || end == -1 || end < start {
+		return uri
 	}
 
-	if testURL.Host == "" {
-		return fmt.Errorf("URI must include a valid host")
+	host := uri[start+1 : end]
+	pct := strings.IndexByte(host, '%')
+	if pct == -1 || strings.HasPrefix(host[pct:], "%25") {
+		return uri
 	}
 
-	return nil
+	return uri[:start+1] + host[:pct] + "%25" + host[pct+1:] + uri[end:]
+}
+
+// ValidateURI reports whether uri is a structurally valid duckarrow endpoint
+// URI. It's a thin wrapper around ParseURI for callers that only need a
+// boolean/error result.
+func ValidateURI(uri string) error {
+	_, err := ParseURI(uri)
+	return err
 }
@@ -0,0 +1,84 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_invalidate_cache_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+
+	"duckdb"
+
+	"main/internal/flight"
+)
+
+// duckarrow_invalidate_cache_callback is the scalar function callback for
+// duckarrow_invalidate_cache(). It clears the process-wide metadata cache so
+// the next GetSchemas/GetTables/GetColumns call hits the Flight SQL server
+// instead of returning a memoized result.
+//
+//export duckarrow_invalidate_cache_callback
+func duckarrow_invalidate_cache_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	_ = info // unused but required by callback signature
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	inputSize := int(C.duckdb_data_chunk_get_size(input))
+	if inputSize == 0 {
+		return
+	}
+
+	flight.InvalidateMetadataCache()
+
+	msgCStr := C.CString("metadata cache invalidated")
+	defer C.free(unsafe.Pointer(msgCStr))
+
+	for i := range inputSize {
+		C.duckdb_vector_assign_string_element(output, C.idx_t(i), msgCStr)
+	}
+}
+
+// RegisterDuckArrowInvalidateCacheFunction registers the
+// duckarrow_invalidate_cache() scalar function.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_invalidate_cache();
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowInvalidateCacheFunction(conn duckdb.Connection) duckdb.State {
+	// Create scalar function
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	// Set name
+	name := C.CString("duckarrow_invalidate_cache")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	// No parameters needed
+
+	// Set VARCHAR return type
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	// Set the callback
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_invalidate_cache_callback))
+
+	// Register the function
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
@@ -13,7 +13,15 @@
 //   - duckarrow_init_wrapper: Table function init phase
 //   - duckarrow_scan_wrapper: Table function scan phase (returns data)
 //   - duckarrow_configure_callback: Scalar function for configuration
+//   - duckarrow_configure_profile_callback: Scalar function for named connection profiles
+//   - duckarrow_configure_tls_callback: Scalar function for mTLS/bearer-token endpoints
+//   - duckarrow_configure_option_callback: Scalar function for runtime tunables (e.g. pool health-check interval)
 //   - duckarrow_version_callback: Scalar function returning extension version
+//   - duckarrow_set_trace_context_callback: Scalar function injecting a W3C traceparent for OTel correlation
+//   - duckarrow_invalidate_cache_callback: Scalar function clearing the catalog metadata cache
+//   - duckarrow_begin_callback, duckarrow_commit_callback, duckarrow_rollback_callback: Scalar functions
+//     grouping duckarrow_execute calls into a transaction pinned to the calling connection
+//   - duckarrow_execute_batch_callback: Scalar function executing a list of statements on one connection
 //   - duckarrow_replacement_scan_callback: Rewrites duckarrow.* table references
 package main
 
@@ -32,13 +40,17 @@ import "C"
 import (
 	"context"
 	"duckdb"
+	"errors"
 	"fmt"
 	"runtime"
 	"runtime/cgo"
 	"strings"
 	"unsafe"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"main/internal/flight"
+	"main/internal/tracing"
 )
 
 //export duckarrow_init_c_api
@@ -86,6 +98,18 @@ func duckarrow_init_c_api(info unsafe.Pointer, access unsafe.Pointer) bool {
 		return false
 	}
 
+	// Register duckarrow_configure_profile scalar function for named connection profiles
+	if state := RegisterDuckArrowConfigureProfileFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_configure_profile function")
+		return false
+	}
+
+	// Register duckarrow_configure_tls scalar function for mTLS/bearer-token endpoints
+	if state := RegisterDuckArrowConfigureTLSFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_configure_tls function")
+		return false
+	}
+
 	// Register duckarrow_version scalar function
 	if state := RegisterDuckArrowVersionFunction(conn); state == duckdb.STATE_ERROR {
 		fmt.Println("[duckarrow] Failed to register duckarrow_version function")
@@ -98,6 +122,45 @@ func duckarrow_init_c_api(info unsafe.Pointer, access unsafe.Pointer) bool {
 		return false
 	}
 
+	// Register duckarrow_configure_option scalar function for runtime tunables
+	if state := RegisterDuckArrowConfigureOptionFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_configure_option function")
+		return false
+	}
+
+	// Register duckarrow_set_trace_context scalar function for OTel trace propagation
+	if state := RegisterDuckArrowSetTraceContextFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_set_trace_context function")
+		return false
+	}
+
+	// Register duckarrow_invalidate_cache scalar function to force a metadata cache refresh
+	if state := RegisterDuckArrowInvalidateCacheFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_invalidate_cache function")
+		return false
+	}
+
+	// Register duckarrow_begin/duckarrow_commit/duckarrow_rollback scalar
+	// functions so duckarrow_execute calls can be grouped into a transaction
+	if state := RegisterDuckArrowBeginFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_begin function")
+		return false
+	}
+	if state := RegisterDuckArrowCommitFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_commit function")
+		return false
+	}
+	if state := RegisterDuckArrowRollbackFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_rollback function")
+		return false
+	}
+
+	// Register duckarrow_execute_batch scalar function
+	if state := RegisterDuckArrowExecuteBatchFunction(conn); state == duckdb.STATE_ERROR {
+		fmt.Println("[duckarrow] Failed to register duckarrow_execute_batch function")
+		return false
+	}
+
 	// Register replacement scan for duckarrow.* tables
 	RegisterReplacementScan(db)
 
@@ -126,11 +189,18 @@ type ConnectionHandle struct {
 // duckarrow_go_connect is called by C++ during ATTACH to establish a Flight SQL connection.
 // It creates a new connection to the Flight SQL server and returns an opaque handle.
 //
+// caCert, clientCert, and clientKey are PEM file paths enabling mTLS;
+// serverNameOverride overrides the hostname validated against the server's
+// certificate. Any of these may be nil/empty when not needed. The
+// ATTACH-time storage extension options (ca_cert, client_cert, client_key,
+// server_name_override) must be threaded through to this call from the C++
+// side; that wiring lives outside this Go source tree.
+//
 // Thread safety: Uses runtime.LockOSThread() as required for CGO callbacks.
 // Memory: The returned error string is allocated with C.CString and must be freed by C++.
 //
 //export duckarrow_go_connect
-func duckarrow_go_connect(uri *C.char, username *C.char, password *C.char, token *C.char) C.DuckArrowConnectResult {
+func duckarrow_go_connect(uri *C.char, username *C.char, password *C.char, token *C.char, caCert *C.char, clientCert *C.char, clientKey *C.char, serverNameOverride *C.char) C.DuckArrowConnectResult {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
@@ -138,8 +208,14 @@ func duckarrow_go_connect(uri *C.char, username *C.char, password *C.char, token
 	result.handle = nil
 	result.error = nil
 
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.connect",
+		attribute.String("uri", C.GoString(uri)))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
 	// Validate URI
 	if uri == nil {
+		opErr = errors.New("URI is required")
 		result.error = C.CString("duckarrow_go_connect: URI is required")
 		return result
 	}
@@ -154,12 +230,26 @@ func duckarrow_go_connect(uri *C.char, username *C.char, password *C.char, token
 	if password != nil {
 		cfg.Password = C.GoString(password)
 	}
-	// Note: token support could be added to flight.Config if needed
+	if token != nil {
+		cfg.BearerToken = C.GoString(token)
+	}
+	if caCert != nil {
+		cfg.TLSCACert = C.GoString(caCert)
+	}
+	if clientCert != nil {
+		cfg.TLSClientCert = C.GoString(clientCert)
+	}
+	if clientKey != nil {
+		cfg.TLSClientKey = C.GoString(clientKey)
+	}
+	if serverNameOverride != nil {
+		cfg.ServerNameOverride = C.GoString(serverNameOverride)
+	}
 
 	// Establish connection to Flight SQL server
-	ctx := context.Background()
 	client, err := flight.Connect(ctx, cfg)
 	if err != nil {
+		opErr = err
 		errMsg := fmt.Sprintf("duckarrow_go_connect: %v", err)
 		result.error = C.CString(errMsg)
 		return result
@@ -195,8 +285,13 @@ func duckarrow_go_list_schemas(connection C.DuckArrowConnectionHandle, catalog *
 	result.count = 0
 	result.error = nil
 
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.list_schemas")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
 	// Validate connection handle
 	if connection == nil {
+		opErr = errors.New("nil connection handle")
 		result.error = C.CString("duckarrow_go_list_schemas: nil connection handle")
 		return result
 	}
@@ -205,18 +300,21 @@ func duckarrow_go_list_schemas(connection C.DuckArrowConnectionHandle, catalog *
 	handle := cgo.Handle(uintptr(connection))
 	connHandle, ok := handle.Value().(*ConnectionHandle)
 	if !ok || connHandle == nil || connHandle.Client == nil {
+		opErr = errors.New("invalid connection handle")
 		result.error = C.CString("duckarrow_go_list_schemas: invalid connection handle")
 		return result
 	}
+	span.SetAttributes(attribute.String("uri", connHandle.Config.URI))
 
 	// Get schemas from Flight SQL server
-	ctx := context.Background()
 	schemas, err := connHandle.Client.GetSchemas(ctx)
 	if err != nil {
+		opErr = err
 		errMsg := fmt.Sprintf("duckarrow_go_list_schemas: %v", err)
 		result.error = C.CString(errMsg)
 		return result
 	}
+	span.SetAttributes(attribute.Int("schema_count", len(schemas)))
 
 	// Handle empty result
 	if len(schemas) == 0 {
@@ -264,8 +362,20 @@ func duckarrow_go_list_tables(connection C.DuckArrowConnectionHandle, catalog *C
 	result.count = 0
 	result.error = nil
 
+	// Convert schema parameter (catalog is currently unused by Flight SQL GetTables)
+	var schemaName string
+	if schema != nil {
+		schemaName = C.GoString(schema)
+	}
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.list_tables",
+		attribute.String("schema", schemaName))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
 	// Validate connection handle
 	if connection == nil {
+		opErr = errors.New("nil connection handle")
 		result.error = C.CString("duckarrow_go_list_tables: nil connection handle")
 		return result
 	}
@@ -274,24 +384,21 @@ func duckarrow_go_list_tables(connection C.DuckArrowConnectionHandle, catalog *C
 	handle := cgo.Handle(uintptr(connection))
 	connHandle, ok := handle.Value().(*ConnectionHandle)
 	if !ok || connHandle == nil || connHandle.Client == nil {
+		opErr = errors.New("invalid connection handle")
 		result.error = C.CString("duckarrow_go_list_tables: invalid connection handle")
 		return result
 	}
-
-	// Convert schema parameter (catalog is currently unused by Flight SQL GetTables)
-	var schemaName string
-	if schema != nil {
-		schemaName = C.GoString(schema)
-	}
+	span.SetAttributes(attribute.String("uri", connHandle.Config.URI))
 
 	// Get tables from Flight SQL server
-	ctx := context.Background()
 	tables, err := connHandle.Client.GetTables(ctx, schemaName)
 	if err != nil {
+		opErr = err
 		errMsg := fmt.Sprintf("duckarrow_go_list_tables: %v", err)
 		result.error = C.CString(errMsg)
 		return result
 	}
+	span.SetAttributes(attribute.Int("table_count", len(tables)))
 
 	// Handle empty result
 	if len(tables) == 0 {
@@ -341,14 +448,31 @@ func duckarrow_go_get_columns(connection C.DuckArrowConnectionHandle, catalog *C
 	result.count = 0
 	result.error = nil
 
+	// Convert C strings to Go strings (needed for span attributes before validation)
+	var tableStr, schemaStr string
+	if table != nil {
+		tableStr = C.GoString(table)
+	}
+	if schema != nil {
+		schemaStr = C.GoString(schema)
+	}
+	// Note: catalog is currently unused as Flight SQL column queries typically don't use catalog
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.get_columns",
+		attribute.String("schema", schemaStr), attribute.String("table", tableStr))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
 	// Validate connection handle
 	if connection == nil {
+		opErr = errors.New("nil connection handle")
 		result.error = C.CString("duckarrow_go_get_columns: nil connection handle")
 		return result
 	}
 
 	// Validate table parameter (required)
 	if table == nil {
+		opErr = errors.New("table name is required")
 		result.error = C.CString("duckarrow_go_get_columns: table name is required")
 		return result
 	}
@@ -357,26 +481,21 @@ func duckarrow_go_get_columns(connection C.DuckArrowConnectionHandle, catalog *C
 	handle := cgo.Handle(uintptr(connection))
 	connHandle, ok := handle.Value().(*ConnectionHandle)
 	if !ok || connHandle == nil || connHandle.Client == nil {
+		opErr = errors.New("invalid connection handle")
 		result.error = C.CString("duckarrow_go_get_columns: invalid connection handle")
 		return result
 	}
-
-	// Convert C strings to Go strings
-	tableStr := C.GoString(table)
-	var schemaStr string
-	if schema != nil {
-		schemaStr = C.GoString(schema)
-	}
-	// Note: catalog is currently unused as Flight SQL column queries typically don't use catalog
+	span.SetAttributes(attribute.String("uri", connHandle.Config.URI))
 
 	// Get columns from Flight SQL server
-	ctx := context.Background()
 	columns, err := connHandle.Client.GetColumns(ctx, schemaStr, tableStr)
 	if err != nil {
+		opErr = err
 		errMsg := fmt.Sprintf("duckarrow_go_get_columns: %v", err)
 		result.error = C.CString(errMsg)
 		return result
 	}
+	span.SetAttributes(attribute.Int("column_count", len(columns)))
 
 	// Handle empty result (table exists but has no columns)
 	if len(columns) == 0 {
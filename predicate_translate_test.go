@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTranslatePredicate(t *testing.T) {
+	bindData := &ScanBindData{
+		ColumnNames: []string{"id", "name", "payload", "created_at"},
+		ColumnTypes: []string{"BIGINT", "VARCHAR", "BLOB", "TIMESTAMP"},
+	}
+
+	tests := []struct {
+		name string
+		p    pushedPredicate
+		want string
+	}{
+		{
+			name: "equals numeric",
+			p:    pushedPredicate{ColumnIndex: 0, Operator: "=", Literal: "5"},
+			want: `"id" = 5`,
+		},
+		{
+			name: "in list",
+			p:    pushedPredicate{ColumnIndex: 0, Operator: "IN", Literal: "1" + predicateListSep + "2" + predicateListSep + "3"},
+			want: `"id" IN (1, 2, 3)`,
+		},
+		{
+			name: "like varchar",
+			p:    pushedPredicate{ColumnIndex: 1, Operator: "LIKE", Literal: "foo%"},
+			want: `"name" LIKE 'foo%'`,
+		},
+		{
+			name: "is null",
+			p:    pushedPredicate{ColumnIndex: 1, Operator: "IS NULL"},
+			want: `"name" IS NULL`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := translatePredicate(bindData, tt.p)
+			if !ok {
+				t.Fatalf("translatePredicate(%+v) ok = false, want true", tt.p)
+			}
+			if got != tt.want {
+				t.Errorf("translatePredicate(%+v) = %q, want %q", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslatePredicateRejectsOutOfRangeColumn(t *testing.T) {
+	bindData := &ScanBindData{
+		ColumnNames: []string{"id"},
+		ColumnTypes: []string{"BIGINT"},
+	}
+
+	if _, ok := translatePredicate(bindData, pushedPredicate{ColumnIndex: 5, Operator: "=", Literal: "1"}); ok {
+		t.Error("translatePredicate: expected ok=false for an out-of-range column index")
+	}
+	if _, ok := translatePredicate(bindData, pushedPredicate{ColumnIndex: -1, Operator: "=", Literal: "1"}); ok {
+		t.Error("translatePredicate: expected ok=false for a negative column index")
+	}
+}
+
+func TestTranslatePredicateRejectsUnknownOperator(t *testing.T) {
+	bindData := &ScanBindData{
+		ColumnNames: []string{"id"},
+		ColumnTypes: []string{"BIGINT"},
+	}
+
+	if _, ok := translatePredicate(bindData, pushedPredicate{ColumnIndex: 0, Operator: "<>", Literal: "1"}); ok {
+		t.Error("translatePredicate: expected ok=false for an unsupported operator")
+	}
+}
+
+func TestTranslatePredicateQuotesColumnName(t *testing.T) {
+	bindData := &ScanBindData{
+		ColumnNames: []string{`weird"name`},
+		ColumnTypes: []string{"BIGINT"},
+	}
+
+	got, ok := translatePredicate(bindData, pushedPredicate{ColumnIndex: 0, Operator: "=", Literal: "1"})
+	if !ok {
+		t.Fatal("translatePredicate ok = false, want true")
+	}
+	want := `"weird""name" = 1`
+	if got != want {
+		t.Errorf("translatePredicate = %q, want %q", got, want)
+	}
+}
+
+func TestFormatPredicateLiteral(t *testing.T) {
+	tests := []struct {
+		name    string
+		colType string
+		literal string
+		want    string
+		wantOK  bool
+	}{
+		{"varchar is quoted and escaped", "VARCHAR", "o'brien", `'o''brien'`, true},
+		{"blob is quoted and escaped", "BLOB", "ab'cd", `'ab''cd'`, true},
+		{"timestamp is cast", "TIMESTAMP", "2024-01-01 00:00:00", `CAST('2024-01-01 00:00:00' AS TIMESTAMP)`, true},
+		{"date is cast", "DATE", "2024-01-01", `CAST('2024-01-01' AS DATE)`, true},
+		{"time is cast", "TIME", "12:00:00", `CAST('12:00:00' AS TIME)`, true},
+		{"decimal is cast with declared width", "DECIMAL(18,2)", "1.50", `CAST(1.50 AS DECIMAL(18,2))`, true},
+		{"bigint passes through unquoted", "BIGINT", "42", "42", true},
+		{"boolean passes through unquoted", "BOOLEAN", "true", "true", true},
+		{"double passes through unquoted", "DOUBLE", "1.5", "1.5", true},
+		{"lowercase type name still matches", "varchar", "x", `'x'`, true},
+		{"unknown type is rejected", "STRUCT", "x", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := formatPredicateLiteral(tt.colType, tt.literal)
+			if ok != tt.wantOK {
+				t.Fatalf("formatPredicateLiteral(%q, %q) ok = %v, want %v", tt.colType, tt.literal, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("formatPredicateLiteral(%q, %q) = %q, want %q", tt.colType, tt.literal, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,54 +2,124 @@ package main
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
+
+	"main/internal/sqlquery"
 )
 
-// extractTableName extracts the table name from a query of the form:
-// SELECT * FROM "tablename" or SELECT * FROM "table""name" (with escaped quotes)
-// Returns the unescaped table name.
+// extractTableName extracts the table name referenced by a simple
+// single-table query of the form SELECT ... FROM "tablename" (optionally
+// schema/catalog-qualified). Returns "" if query isn't a simple table scan
+// - a join, a CTE, a subquery, or anything else sqlquery.Parse doesn't
+// resolve to a single relation.
 func extractTableName(query string) string {
-	// Match SELECT * FROM "tablename" pattern
-	// The table name may contain escaped double quotes (doubled)
-	re := regexp.MustCompile(`(?i)SELECT\s+\*\s+FROM\s+"([^"]*(?:""[^"]*)*)"`)
-	matches := re.FindStringSubmatch(query)
-	if len(matches) >= 2 {
-		// Unescape doubled quotes
-		return strings.ReplaceAll(matches[1], `""`, `"`)
-	}
-	// Fallback: try without quotes (shouldn't happen from replacement scan)
-	re = regexp.MustCompile(`(?i)SELECT\s+\*\s+FROM\s+(\S+)`)
-	matches = re.FindStringSubmatch(query)
-	if len(matches) >= 2 {
-		return matches[1]
-	}
-	return ""
+	q, err := sqlquery.Parse(query)
+	if err != nil || q.Relation == nil {
+		return ""
+	}
+	return q.Relation.Table
+}
+
+// buildProjectedQuery narrows q's projection to columns, preserving any
+// WHERE/GROUP BY/ORDER BY/LIMIT clauses q already had. If columns is empty,
+// q's own projection is kept (falling back to "*" if q selected everything).
+//
+// For a simple single-table scan, the result selects straight from the
+// relation. For anything else - joins, CTEs, subqueries - q.Original is
+// wrapped as a subquery, which narrows the projection without this package
+// needing to understand the inner query's shape.
+func buildProjectedQuery(q *sqlquery.Query, columns []string) string {
+	columnList := columnListOrDefault(columns, q.Projection)
+
+	if q.Relation != nil {
+		query := fmt.Sprintf(`SELECT %s FROM %s`, columnList, q.Relation.QualifiedName())
+		return appendClauses(query, q.Where, q.GroupBy, q.OrderBy, q.Limit)
+	}
+
+	return fmt.Sprintf(`SELECT %s FROM (%s) AS duckarrow_q`, columnList, q.Original)
+}
+
+// buildSchemaQuery constructs a query that returns only the schema (no
+// rows). A simple table scan is queried directly with WHERE 1=0; anything
+// else is wrapped as a subquery so arbitrarily complex queries still
+// discover their output schema without this package needing to understand
+// their shape.
+func buildSchemaQuery(q *sqlquery.Query) string {
+	if q.Relation != nil {
+		return fmt.Sprintf(`SELECT * FROM %s WHERE 1=0`, q.Relation.QualifiedName())
+	}
+	return fmt.Sprintf(`SELECT * FROM (%s) WHERE 1=0`, q.Original)
 }
 
-// buildProjectedQuery constructs a SQL query with specific columns.
-// If columns is empty, uses SELECT *.
-// tableName should be unescaped; this function handles escaping.
-func buildProjectedQuery(tableName string, columns []string) string {
-	escapedTable := strings.ReplaceAll(tableName, `"`, `""`)
+// buildFilteredQuery constructs a SQL query with specific columns and a
+// pushed-down WHERE clause, preserving q's existing WHERE/GROUP BY/ORDER
+// BY/LIMIT clauses. filters are already-rendered predicate fragments (e.g.
+// `"col" > 5`) joined with AND; callers are responsible for building each
+// fragment safely, the same contract buildProjectedQuery places on columns.
+// An empty filters slice adds no extra predicate.
+func buildFilteredQuery(q *sqlquery.Query, columns []string, filters []string) string {
+	columnList := columnListOrDefault(columns, q.Projection)
+	pushedWhere := strings.Join(filters, " AND ")
+
+	if q.Relation != nil {
+		query := fmt.Sprintf(`SELECT %s FROM %s`, columnList, q.Relation.QualifiedName())
+		return appendClauses(query, combineWhere(q.Where, pushedWhere), q.GroupBy, q.OrderBy, q.Limit)
+	}
 
-	var columnList string
+	query := fmt.Sprintf(`SELECT %s FROM (%s) AS duckarrow_q`, columnList, q.Original)
+	if pushedWhere != "" {
+		query = fmt.Sprintf("%s WHERE %s", query, pushedWhere)
+	}
+	return query
+}
+
+// columnListOrDefault renders columns (quoted) as a comma-separated SELECT
+// list, falling back to fallback (the query's own existing projection, used
+// verbatim since it's already valid SQL text) when columns is empty, and to
+// "*" when both are empty.
+func columnListOrDefault(columns []string, fallback []string) string {
 	if len(columns) == 0 {
-		columnList = "*"
-	} else {
-		escapedCols := make([]string, len(columns))
-		for i, col := range columns {
-			escapedCols[i] = fmt.Sprintf(`"%s"`, strings.ReplaceAll(col, `"`, `""`))
+		if len(fallback) == 0 {
+			return "*"
 		}
-		columnList = strings.Join(escapedCols, ", ")
+		return strings.Join(fallback, ", ")
 	}
 
-	return fmt.Sprintf(`SELECT %s FROM "%s"`, columnList, escapedTable)
+	escaped := make([]string, len(columns))
+	for i, col := range columns {
+		escaped[i] = fmt.Sprintf(`"%s"`, strings.ReplaceAll(col, `"`, `""`))
+	}
+	return strings.Join(escaped, ", ")
 }
 
-// buildSchemaQuery constructs a query that returns only the schema (no rows).
-// Uses WHERE 1=0 to avoid fetching any data.
-func buildSchemaQuery(tableName string) string {
-	escapedTable := strings.ReplaceAll(tableName, `"`, `""`)
-	return fmt.Sprintf(`SELECT * FROM "%s" WHERE 1=0`, escapedTable)
+// combineWhere ANDs a pushed-down predicate onto a query's existing WHERE
+// clause, parenthesizing the original so its operator precedence (e.g. an
+// OR) isn't changed by the addition.
+func combineWhere(original, pushed string) string {
+	switch {
+	case original == "":
+		return pushed
+	case pushed == "":
+		return original
+	default:
+		return fmt.Sprintf("(%s) AND %s", original, pushed)
+	}
+}
+
+// appendClauses appends WHERE/GROUP BY/ORDER BY/LIMIT to query, skipping
+// any clause that's empty.
+func appendClauses(query, where, groupBy, orderBy, limit string) string {
+	if where != "" {
+		query = fmt.Sprintf("%s WHERE %s", query, where)
+	}
+	if groupBy != "" {
+		query = fmt.Sprintf("%s GROUP BY %s", query, groupBy)
+	}
+	if orderBy != "" {
+		query = fmt.Sprintf("%s ORDER BY %s", query, orderBy)
+	}
+	if limit != "" {
+		query = fmt.Sprintf("%s LIMIT %s", query, limit)
+	}
+	return query
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"testing"
+
+	"main/internal/sqlquery"
 )
 
 func TestExtractTableName(t *testing.T) {
@@ -50,6 +52,26 @@ func TestExtractTableName(t *testing.T) {
 			query:    "INSERT INTO table VALUES (1)",
 			expected: "",
 		},
+		{
+			name:     "schema-qualified table",
+			query:    `SELECT * FROM "myschema"."Order"`,
+			expected: "Order",
+		},
+		{
+			name:     "unquoted table name",
+			query:    `SELECT * FROM Customers`,
+			expected: "customers",
+		},
+		{
+			name:     "join falls back to empty",
+			query:    `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id"`,
+			expected: "",
+		},
+		{
+			name:     "query with WHERE still extracts table",
+			query:    `SELECT * FROM "Order" WHERE "status" = 'shipped'`,
+			expected: "Order",
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,54 +86,133 @@ func TestExtractTableName(t *testing.T) {
 
 func TestBuildProjectedQuery(t *testing.T) {
 	tests := []struct {
-		name      string
-		tableName string
-		columns   []string
-		expected  string
+		name     string
+		query    *sqlquery.Query
+		columns  []string
+		expected string
 	}{
 		{
-			name:      "single column",
-			tableName: "Order",
-			columns:   []string{"id"},
-			expected:  `SELECT "id" FROM "Order"`,
+			name:     "single column",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{"id"},
+			expected: `SELECT "id" FROM "Order"`,
+		},
+		{
+			name:     "multiple columns",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{"id", "name", "status"},
+			expected: `SELECT "id", "name", "status" FROM "Order"`,
+		},
+		{
+			name:     "empty columns - SELECT *",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{},
+			expected: `SELECT * FROM "Order"`,
+		},
+		{
+			name:     "nil columns - SELECT *",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  nil,
+			expected: `SELECT * FROM "Order"`,
+		},
+		{
+			name:     "table name with quotes",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: `My"Table`}},
+			columns:  []string{"col1"},
+			expected: `SELECT "col1" FROM "My""Table"`,
+		},
+		{
+			name:     "column name with quotes",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{`col"1`, "col2"},
+			expected: `SELECT "col""1", "col2" FROM "Order"`,
+		},
+		{
+			name:     "schema-qualified table",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Schema: "s", Table: "Order"}},
+			columns:  []string{"id"},
+			expected: `SELECT "id" FROM "s"."Order"`,
 		},
 		{
-			name:      "multiple columns",
-			tableName: "Order",
-			columns:   []string{"id", "name", "status"},
-			expected:  `SELECT "id", "name", "status" FROM "Order"`,
+			name:     "preserves original WHERE/ORDER BY/LIMIT",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}, Where: `"status" = 'shipped'`, OrderBy: `"id" DESC`, Limit: "10"},
+			columns:  []string{"id"},
+			expected: `SELECT "id" FROM "Order" WHERE "status" = 'shipped' ORDER BY "id" DESC LIMIT 10`,
 		},
 		{
-			name:      "empty columns - SELECT *",
-			tableName: "Order",
-			columns:   []string{},
-			expected:  `SELECT * FROM "Order"`,
+			name:     "empty columns falls back to original projection",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}, Projection: []string{`"id"`, `"name"`}},
+			columns:  nil,
+			expected: `SELECT "id", "name" FROM "Order"`,
 		},
 		{
-			name:      "nil columns - SELECT *",
-			tableName: "Order",
-			columns:   nil,
-			expected:  `SELECT * FROM "Order"`,
+			name:     "non-simple query wraps original as a subquery",
+			query:    &sqlquery.Query{Original: `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id"`},
+			columns:  []string{"id"},
+			expected: `SELECT "id" FROM (SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id") AS duckarrow_q`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildProjectedQuery(tt.query, tt.columns)
+			if result != tt.expected {
+				t.Errorf("buildProjectedQuery(%+v, %v) = %q, want %q", tt.query, tt.columns, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildFilteredQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *sqlquery.Query
+		columns  []string
+		filters  []string
+		expected string
+	}{
+		{
+			name:     "no filters falls back to projected query",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{"id"},
+			filters:  nil,
+			expected: `SELECT "id" FROM "Order"`,
+		},
+		{
+			name:     "single filter",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  []string{"id", "status"},
+			filters:  []string{`"status" = 'shipped'`},
+			expected: `SELECT "id", "status" FROM "Order" WHERE "status" = 'shipped'`,
 		},
 		{
-			name:      "table name with quotes",
-			tableName: `My"Table`,
-			columns:   []string{"col1"},
-			expected:  `SELECT "col1" FROM "My""Table"`,
+			name:     "multiple filters joined with AND",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			columns:  nil,
+			filters:  []string{`"id" > 5`, `"id" < 100`},
+			expected: `SELECT * FROM "Order" WHERE "id" > 5 AND "id" < 100`,
 		},
 		{
-			name:      "column name with quotes",
-			tableName: "Order",
-			columns:   []string{`col"1`, "col2"},
-			expected:  `SELECT "col""1", "col2" FROM "Order"`,
+			name:     "combines with existing WHERE",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}, Where: `"status" = 'shipped'`},
+			columns:  []string{"id"},
+			filters:  []string{`"id" > 5`},
+			expected: `SELECT "id" FROM "Order" WHERE ("status" = 'shipped') AND "id" > 5`,
+		},
+		{
+			name:     "non-simple query wraps original as a subquery",
+			query:    &sqlquery.Query{Original: `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id"`},
+			columns:  []string{"id"},
+			filters:  []string{`"id" > 5`},
+			expected: `SELECT "id" FROM (SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id") AS duckarrow_q WHERE "id" > 5`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildProjectedQuery(tt.tableName, tt.columns)
+			result := buildFilteredQuery(tt.query, tt.columns, tt.filters)
 			if result != tt.expected {
-				t.Errorf("buildProjectedQuery(%q, %v) = %q, want %q", tt.tableName, tt.columns, result, tt.expected)
+				t.Errorf("buildFilteredQuery(%+v, %v, %v) = %q, want %q", tt.query, tt.columns, tt.filters, result, tt.expected)
 			}
 		})
 	}
@@ -119,27 +220,32 @@ func TestBuildProjectedQuery(t *testing.T) {
 
 func TestBuildSchemaQuery(t *testing.T) {
 	tests := []struct {
-		name      string
-		tableName string
-		expected  string
+		name     string
+		query    *sqlquery.Query
+		expected string
 	}{
 		{
-			name:      "simple table",
-			tableName: "Order",
-			expected:  `SELECT * FROM "Order" WHERE 1=0`,
+			name:     "simple table",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: "Order"}},
+			expected: `SELECT * FROM "Order" WHERE 1=0`,
+		},
+		{
+			name:     "table with quotes",
+			query:    &sqlquery.Query{Relation: &sqlquery.Relation{Table: `My"Table`}},
+			expected: `SELECT * FROM "My""Table" WHERE 1=0`,
 		},
 		{
-			name:      "table with quotes",
-			tableName: `My"Table`,
-			expected:  `SELECT * FROM "My""Table" WHERE 1=0`,
+			name:     "non-simple query wraps original",
+			query:    &sqlquery.Query{Original: `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id"`},
+			expected: `SELECT * FROM (SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id") WHERE 1=0`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildSchemaQuery(tt.tableName)
+			result := buildSchemaQuery(tt.query)
 			if result != tt.expected {
-				t.Errorf("buildSchemaQuery(%q) = %q, want %q", tt.tableName, result, tt.expected)
+				t.Errorf("buildSchemaQuery(%+v) = %q, want %q", tt.query, result, tt.expected)
 			}
 		})
 	}
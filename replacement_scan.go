@@ -18,58 +18,104 @@ import (
 	"sync"
 	"unsafe"
 
+	"main/internal/flight"
 	"main/internal/validation"
 )
 
-// DuckArrowConfig holds the global configuration for duckarrow replacement scan.
+// defaultProfileName is the profile used by duckarrow_configure() and by
+// unqualified duckarrow.<table> references, preserving the single-endpoint
+// behavior this extension had before named profiles were introduced.
+const defaultProfileName = "default"
+
+// DuckArrowConfig holds the global configuration for duckarrow replacement scan,
+// keyed by profile name. This lets one DuckDB session federate queries across
+// several Flight SQL servers: each profile is configured independently via
+// duckarrow_configure()/duckarrow_configure_tls() (the "default" profile) or
+// duckarrow_configure_profile(), and resolved at query time via the
+// replacement scan callback below. Profiles are stored as flight.Config so
+// TLS client certs, CA bundles, and bearer tokens configured from SQL flow
+// straight into the same struct flight.Pool keys connections by.
 //
 // Thread Safety and TOCTOU Considerations:
 // The configuration is protected by a RWMutex for concurrent access. However, there is an
-// intentional TOCTOU (time-of-check-time-of-use) window: if SetDuckArrowConfig is called
+// intentional TOCTOU (time-of-check-time-of-use) window: if a profile is reconfigured
 // while a query is in progress, the in-flight query will complete with the old config,
 // while new queries will use the new config. This is acceptable behavior because:
 //   - Each query gets a consistent configuration for its entire execution
 //   - Config changes are rare (typically once at session start)
 //   - The alternative (locking for entire query duration) would cause deadlocks
 //
-// Users should call duckarrow_configure() before running queries, not during.
+// Users should call duckarrow_configure()/duckarrow_configure_profile() before
+// running queries, not during.
 type DuckArrowConfig struct {
-	mu         sync.RWMutex
-	uri        string
-	username   string
-	password   string
-	skipVerify bool
+	mu       sync.RWMutex
+	profiles map[string]flight.Config
 }
 
 var duckArrowConfig = &DuckArrowConfig{}
 
-// SetDuckArrowConfig sets the connection configuration for duckarrow.
+// SetDuckArrowConfig sets the connection configuration for the default profile.
 // This is called by duckarrow_configure() and takes effect for subsequent queries.
 // In-flight queries will complete with the previous configuration.
 func SetDuckArrowConfig(uri, username, password string, skipVerify bool) {
+	SetDuckArrowProfile(defaultProfileName, uri, username, password, skipVerify)
+}
+
+// SetDuckArrowProfile sets the connection configuration for a named profile.
+// This is called by duckarrow_configure_profile() and takes effect for
+// subsequent queries against duckarrow_<name>.* or duckarrow.<name>.* tables.
+func SetDuckArrowProfile(name, uri, username, password string, skipVerify bool) {
+	SetDuckArrowProfileConfig(name, flight.Config{
+		URI:        uri,
+		Username:   username,
+		Password:   password,
+		SkipVerify: skipVerify,
+	})
+}
+
+// SetDuckArrowProfileConfig sets the full connection configuration (including
+// TLS client certs/CA and bearer token) for a named profile. This is called
+// by duckarrow_configure_tls() for mTLS/bearer-token endpoints that don't fit
+// the username/password signature of duckarrow_configure_profile().
+func SetDuckArrowProfileConfig(name string, cfg flight.Config) {
 	duckArrowConfig.mu.Lock()
 	defer duckArrowConfig.mu.Unlock()
-	duckArrowConfig.uri = uri
-	duckArrowConfig.username = username
-	duckArrowConfig.password = password
-	duckArrowConfig.skipVerify = skipVerify
+	if duckArrowConfig.profiles == nil {
+		duckArrowConfig.profiles = make(map[string]flight.Config)
+	}
+	duckArrowConfig.profiles[name] = cfg
 }
 
-// GetDuckArrowConfig gets the current duckarrow configuration.
+// GetDuckArrowConfig gets the current configuration for the default profile.
 // Returns empty strings if not configured, which causes replacement scan to skip.
 // skipVerify defaults to false (secure) if not explicitly set.
 func GetDuckArrowConfig() (uri, username, password string, skipVerify bool) {
+	return GetDuckArrowProfileConfig(defaultProfileName)
+}
+
+// GetDuckArrowProfileConfig gets the current basic-auth configuration for a
+// named profile. Returns empty strings if the profile is unconfigured, which
+// causes replacement scan to skip. Use GetDuckArrowProfileFullConfig for the
+// TLS/bearer-token fields.
+func GetDuckArrowProfileConfig(name string) (uri, username, password string, skipVerify bool) {
+	cfg := GetDuckArrowProfileFullConfig(name)
+	return cfg.URI, cfg.Username, cfg.Password, cfg.SkipVerify
+}
+
+// GetDuckArrowProfileFullConfig gets the full flight.Config for a named
+// profile, including TLS client certs/CA and bearer token. Returns the zero
+// value if the profile is unconfigured.
+func GetDuckArrowProfileFullConfig(name string) flight.Config {
 	duckArrowConfig.mu.RLock()
 	defer duckArrowConfig.mu.RUnlock()
-	return duckArrowConfig.uri, duckArrowConfig.username, duckArrowConfig.password, duckArrowConfig.skipVerify
+	return duckArrowConfig.profiles[name]
 }
 
-// GetDuckArrowURI gets the current duckarrow URI (for backward compatibility).
-// Returns empty string if not configured, which causes replacement scan to skip.
+// GetDuckArrowURI gets the current duckarrow URI for the default profile
+// (for backward compatibility). Returns empty string if not configured, which
+// causes replacement scan to skip.
 func GetDuckArrowURI() string {
-	duckArrowConfig.mu.RLock()
-	defer duckArrowConfig.mu.RUnlock()
-	return duckArrowConfig.uri
+	return GetDuckArrowProfileFullConfig(defaultProfileName).URI
 }
 
 // validateTableName delegates to the validation package for testability.
@@ -77,12 +123,73 @@ func validateTableName(name string) error {
 	return validation.ValidateTableName(name)
 }
 
+// stripOneQuotePair strips exactly one pair of surrounding double quotes if
+// present (DuckDB may pass quoted identifiers). Using strings.Trim would
+// incorrectly strip ALL quotes from names like ""table"" -> table; instead we
+// only strip one pair: ""table"" -> "table" (a table name containing quotes).
+func stripOneQuotePair(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// splitQualifiedName splits name on the first unquoted '.', so that quoted
+// identifiers containing their own '.' characters aren't misread as
+// qualifiers. Returns qualified=false if there is no top-level '.'.
+func splitQualifiedName(name string) (prefix, rest string, qualified bool) {
+	inQuote := false
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '"':
+			inQuote = !inQuote
+		case '.':
+			if !inQuote {
+				return name[:i], name[i+1:], true
+			}
+		}
+	}
+	return "", name, false
+}
+
+// resolveProfile extracts an optional named-profile qualifier from the
+// replacement scan's table reference, supporting both
+// duckarrow_<profile>.tablename and duckarrow.<profile>.tablename. When
+// neither form matches, it falls back to the default profile and the name is
+// treated as an unqualified table reference, preserving today's behavior.
+func resolveProfile(name string) (profile, table string) {
+	first, rest, qualified := splitQualifiedName(name)
+	if !qualified {
+		return defaultProfileName, stripOneQuotePair(name)
+	}
+	first = stripOneQuotePair(first)
+
+	// duckarrow.<profile>.tablename: the first segment is the literal
+	// "duckarrow" schema and the second segment names the profile.
+	if first == "duckarrow" {
+		second, table, qualified := splitQualifiedName(rest)
+		if qualified {
+			return stripOneQuotePair(second), stripOneQuotePair(table)
+		}
+		return defaultProfileName, stripOneQuotePair(rest)
+	}
+
+	// duckarrow_<profile>.tablename: the profile is embedded in the schema name.
+	if strings.HasPrefix(first, "duckarrow_") {
+		return strings.TrimPrefix(first, "duckarrow_"), stripOneQuotePair(rest)
+	}
+
+	return defaultProfileName, stripOneQuotePair(name)
+}
+
 // duckarrow_replacement_scan_callback is called by DuckDB when it encounters an unknown table
-// in the "duckarrow" schema. It rewrites the query to use our duckarrow_query table function.
+// in the "duckarrow" schema (or a duckarrow_<profile> schema). It rewrites the
+// query to use our duckarrow_query table function, resolved against the
+// matching named profile.
 //
 // Thread safety: This callback may be invoked from multiple DuckDB threads concurrently.
-// The URI is read atomically via GetDuckArrowURI(). If the URI changes during query execution,
-// subsequent queries will use the new URI.
+// The profile's URI is read atomically via GetDuckArrowProfileConfig(). If the
+// profile changes during query execution, subsequent queries will use the new URI.
 //
 //export duckarrow_replacement_scan_callback
 func duckarrow_replacement_scan_callback(info C.duckdb_replacement_scan_info, tableName *C.char, extraData unsafe.Pointer) {
@@ -91,21 +198,15 @@ func duckarrow_replacement_scan_callback(info C.duckdb_replacement_scan_info, ta
 
 	name := C.GoString(tableName)
 
-	// Get the configured URI
-	uri := GetDuckArrowURI()
+	profile, actualTable := resolveProfile(name)
+
+	// Get the configured URI for the resolved profile
+	uri, _, _, _ := GetDuckArrowProfileConfig(profile)
 	if uri == "" {
 		// Not configured yet - don't handle this table
 		return
 	}
 
-	// Strip exactly one pair of surrounding quotes if present (DuckDB may pass quoted identifiers)
-	// Using strings.Trim would incorrectly strip ALL quotes from names like ""table"" -> table
-	// Instead, we only strip one pair: ""table"" -> "table" (a table name containing quotes)
-	actualTable := name
-	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
-		actualTable = name[1 : len(name)-1]
-	}
-
 	// Skip if it looks like a DuckDB internal or system table
 	if validation.ShouldSkipTable(actualTable) {
 		return
@@ -123,7 +224,14 @@ func duckarrow_replacement_scan_callback(info C.duckdb_replacement_scan_info, ta
 	// In SQL, a literal double quote inside a quoted identifier is escaped by doubling it
 	escapedTable := strings.ReplaceAll(actualTable, `"`, `""`)
 
-	// Generate the query - quote the table name for safety
+	// Generate the query - quote the table name for safety.
+	//
+	// This always selects every column with no filter: DuckDB invokes the
+	// replacement scan before binding, so the requested projection and any
+	// pushed-down predicates aren't known yet here. Real column/filter
+	// push-down happens one layer down, in the scan's bind/init (see
+	// duckarrow_go_scan_init/_ex, which receive the bound column IDs and
+	// pushed predicates for the attached-database path).
 	query := fmt.Sprintf(`SELECT * FROM "%s"`, escapedTable)
 
 	// Set the function name to our table function
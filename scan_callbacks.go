@@ -14,15 +14,21 @@ import (
 	"fmt"
 	"runtime"
 	"runtime/cgo"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"unsafe"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"main/internal/flight"
+	"main/internal/tracing"
 
-	"github.com/apache/arrow-adbc/go/adbc"
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 )
 
 // ScanBindData holds state for a table scan bound via GetScanFunction.
@@ -32,30 +38,123 @@ type ScanBindData struct {
 	Client   *flight.Client
 	Config   flight.Config
 	IsPooled bool
+	Lease    *flight.Lease
 	URI      string
 
 	// Table identification
 	SchemaName string
 	TableName  string
 
+	// RawQuery and Params are set instead of SchemaName/TableName when this
+	// bind came from duckarrow_go_query_bind (the flight_query(sql, ...)
+	// table function) rather than duckarrow_go_scan_table_bind: RawQuery is
+	// the already placeholder-rewritten SQL text (see flight.ParseQueryParams)
+	// and Params are the positional values to bind against it. scanFromClause
+	// wraps RawQuery as a subquery so the rest of the scan/init machinery -
+	// column listing, projection, predicate pushdown - is unchanged.
+	RawQuery string
+	Params   []any
+
 	// Column metadata (populated during bind)
 	ColumnNames []string
 	ColumnTypes []string
 
-	// Query state (populated during init)
-	Stmt   adbc.Statement
-	Reader array.RecordReader
-	Schema *arrow.Schema
+	// Query state (populated during init). Query holds every Flight
+	// endpoint GetFlightInfo returned; duckarrow_go_scan_max_threads and
+	// duckarrow_go_scan_init_local fan out across it, with nextPartition as
+	// the claim counter concurrent worker threads increment to each pick a
+	// distinct endpoint.
+	Query         *flight.PartitionedQuery
+	nextPartition int32
+	Schema        *arrow.Schema
+
+	// scanCursor is only used as a fallback when GetFlightInfo returned a
+	// single endpoint, in which case there is nothing to parallelize and
+	// duckarrow_go_scan_init_local hands every worker thread this same
+	// bindData handle instead of a per-thread ScanThreadState.
+	scanCursor
+
+	// threadStates tracks every ScanThreadState duckarrow_go_scan_init_local
+	// has handed out that duckarrow_go_scan_free_local hasn't yet released,
+	// so closing the global scan can still release any a worker thread
+	// never got to (e.g. a scan that errors out before every thread runs).
+	threadStatesMu sync.Mutex
+	threadStates   []cgo.Handle
+
+	// Projection (populated during init)
+	ProjectedColumns []int // Indices of columns to fetch
+
+	// Predicates holds every filter DuckDB pushed down in
+	// duckarrow_go_scan_init_ex (accepted or rejected), used by
+	// duckarrow_go_scan_next to skip whole Arrow batches via
+	// batchSkippable/extractColumnStats when a batch's own min/max/
+	// null_count metadata proves it can't match. nil for a scan bound
+	// through duckarrow_go_scan_init, which never calls the _ex path.
+	Predicates []pushedPredicate
+
+	// batchesRead and batchesSkipped are duckarrow_go_scan_stats'
+	// read/skipped batch counters, incremented from duckarrow_go_scan_next.
+	// Shared across every duckarrow_go_scan_init_local thread of a parallel
+	// scan, hence atomic rather than plain int64.
+	batchesRead    int64
+	batchesSkipped int64
+}
 
-	// Scan state
+// scanCursor is the read position a single duckarrow_go_scan_next call
+// advances: the Arrow RecordReader for one Flight endpoint plus the
+// DuckDB-chunk-sized batch currently being sliced into output vectors.
+// ScanBindData embeds one for the single-endpoint fallback path, and
+// ScanThreadState embeds one per worker thread of a parallel scan.
+type scanCursor struct {
+	Reader        array.RecordReader
 	CurrentBatch  arrow.RecordBatch
 	BatchPosition int64
 	Done          int32
+}
 
-	// Projection (populated during init)
-	ProjectedColumns []int // Indices of columns to fetch
+// ScanThreadState is the per-DuckDB-worker-thread state for a parallel
+// scan: one Flight endpoint's DoGet stream plus its own scanCursor. It is
+// handed out by duckarrow_go_scan_init_local under its own cgo.Handle,
+// independent of the bindData handle the thread was initialized from, and
+// released by duckarrow_go_scan_free_local.
+type ScanThreadState struct {
+	Bind *ScanBindData
+	scanCursor
+}
+
+// forgetThreadHandle removes handle from bind's tracked thread states once
+// its owning duckarrow_go_scan_free_local call has released it, so the
+// duckarrow_go_scan_free backstop doesn't release it a second time.
+func (b *ScanBindData) forgetThreadHandle(handle cgo.Handle) {
+	b.threadStatesMu.Lock()
+	defer b.threadStatesMu.Unlock()
+	for i, h := range b.threadStates {
+		if h == handle {
+			b.threadStates = append(b.threadStates[:i], b.threadStates[i+1:]...)
+			return
+		}
+	}
+}
+
+// pushedPredicate is one filter DuckDB's ComplexFilterPushdown hook has
+// offered to push down to the remote server, as passed from C++ to
+// duckarrow_go_scan_init_ex via parallel C arrays (mirroring how columnIDs
+// is passed to duckarrow_go_scan_init).
+type pushedPredicate struct {
+	ColumnIndex int
+	// Operator is one of "=", "<", "<=", ">", ">=", "IN", "IS NULL", "LIKE".
+	Operator string
+	// Literal is the already-extracted constant(s) for the predicate, as
+	// text. For "IN" it is multiple values joined by predicateListSep; for
+	// "IS NULL" it is unused.
+	Literal string
 }
 
+// predicateListSep separates the per-value literals of an IN predicate.
+// Using a control character rather than a comma avoids ambiguity with
+// string literals that themselves contain commas.
+const predicateListSep = "\x1f"
+
 // duckarrow_go_scan_table_bind binds a scan for a table in an attached database.
 // This creates the connection and gets column metadata.
 //
@@ -82,21 +181,20 @@ func duckarrow_go_scan_table_bind(uri *C.char, schemaName *C.char, tableName *C.
 		schemaStr = C.GoString(schemaName)
 	}
 
-	// Get credentials from global config
-	_, configUsername, configPassword, configSkipVerify := GetDuckArrowConfig()
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.scan_bind",
+		attribute.String("uri", uriStr), attribute.String("schema", schemaStr), attribute.String("table", tableStr))
+	defer func() { tracing.EndWithError(span, opErr) }()
 
-	// Build connection config
-	cfg := flight.Config{
-		URI:        uriStr,
-		Username:   configUsername,
-		Password:   configPassword,
-		SkipVerify: configSkipVerify,
-	}
+	// Get credentials (including any mTLS/bearer-token settings) from the
+	// default profile's global config; only the URI is overridden per-call.
+	cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+	cfg.URI = uriStr
 
 	// Get connection from pool
-	ctx := context.Background()
 	connResult, err := flight.GetConnection(ctx, cfg)
 	if err != nil {
+		opErr = err
 		result.error = C.CString(fmt.Sprintf("duckarrow_go_scan_table_bind: connection failed: %v", err))
 		return result
 	}
@@ -116,8 +214,9 @@ func duckarrow_go_scan_table_bind(uri *C.char, schemaName *C.char, tableName *C.
 	// Execute schema query
 	queryResult, err := connResult.Client.Query(ctx, query)
 	if err != nil {
+		opErr = err
 		if connResult.IsPooled {
-			flight.ReleaseConnection(cfg)
+			flight.ReleaseConnection(connResult.Lease)
 		} else {
 			connResult.Client.Close()
 		}
@@ -143,6 +242,7 @@ func duckarrow_go_scan_table_bind(uri *C.char, schemaName *C.char, tableName *C.
 		Client:      connResult.Client,
 		Config:      cfg,
 		IsPooled:    connResult.IsPooled,
+		Lease:       connResult.Lease,
 		URI:         uriStr,
 		SchemaName:  schemaStr,
 		TableName:   tableStr,
@@ -187,6 +287,10 @@ func arrowTypeToString(t arrow.DataType) string {
 	case arrow.BOOL:
 		return "BOOLEAN"
 	case arrow.TIMESTAMP:
+		dt := t.(*arrow.TimestampType)
+		if dt.TimeZone != "" {
+			return "TIMESTAMP WITH TIME ZONE"
+		}
 		return "TIMESTAMP"
 	case arrow.DATE32, arrow.DATE64:
 		return "DATE"
@@ -197,6 +301,25 @@ func arrowTypeToString(t arrow.DataType) string {
 	case arrow.DECIMAL128:
 		dt := t.(*arrow.Decimal128Type)
 		return fmt.Sprintf("DECIMAL(%d,%d)", dt.Precision, dt.Scale)
+	case arrow.LIST:
+		dt := t.(*arrow.ListType)
+		return arrowTypeToString(dt.Elem()) + "[]"
+	case arrow.LARGE_LIST:
+		dt := t.(*arrow.LargeListType)
+		return arrowTypeToString(dt.Elem()) + "[]"
+	case arrow.STRUCT:
+		dt := t.(*arrow.StructType)
+		fields := make([]string, dt.NumFields())
+		for i, f := range dt.Fields() {
+			fields[i] = fmt.Sprintf("%s %s", f.Name, arrowTypeToString(f.Type))
+		}
+		return fmt.Sprintf("STRUCT(%s)", strings.Join(fields, ", "))
+	case arrow.MAP:
+		dt := t.(*arrow.MapType)
+		return fmt.Sprintf("MAP(%s, %s)", arrowTypeToString(dt.KeyType()), arrowTypeToString(dt.ItemType()))
+	case arrow.DICTIONARY:
+		dt := t.(*arrow.DictionaryType)
+		return arrowTypeToString(dt.ValueType)
 	default:
 		return "VARCHAR"
 	}
@@ -278,30 +401,615 @@ func duckarrow_go_scan_init(scanHandle C.DuckArrowScanHandle, columnIDs *C.size_
 		columnList = strings.Join(cols, ", ")
 	}
 
-	var query string
+	query := fmt.Sprintf("SELECT %s FROM %s", columnList, scanFromClause(bindData))
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.scan_init",
+		attribute.String("uri", bindData.URI), attribute.String("schema", bindData.SchemaName),
+		attribute.String("table", bindData.TableName))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	// Get the Flight endpoints for the query and open the first one (or the
+	// only one, when the server didn't partition the result).
+	if err := beginScan(ctx, bindData, query); err != nil {
+		opErr = err
+		return C.CString(fmt.Sprintf("duckarrow_go_scan_init: %v", err))
+	}
+
+	return nil // Success
+}
+
+// scanFromClause returns the FROM-clause target for bindData: a quoted,
+// schema-qualified table reference for a duckarrow_go_scan_table_bind scan,
+// or bindData.RawQuery wrapped as a subquery for a flight_query
+// (duckarrow_go_query_bind) bind - the same wrapping the bind-time schema
+// discovery query used to populate ColumnNames/ColumnTypes.
+func scanFromClause(bindData *ScanBindData) string {
+	if bindData.RawQuery != "" {
+		return fmt.Sprintf("(%s) AS duckarrow_query", bindData.RawQuery)
+	}
 	if bindData.SchemaName != "" {
 		escapedSchema := strings.ReplaceAll(bindData.SchemaName, `"`, `""`)
 		escapedTable := strings.ReplaceAll(bindData.TableName, `"`, `""`)
-		query = fmt.Sprintf(`SELECT %s FROM "%s"."%s"`, columnList, escapedSchema, escapedTable)
+		return fmt.Sprintf(`"%s"."%s"`, escapedSchema, escapedTable)
+	}
+	escapedTable := strings.ReplaceAll(bindData.TableName, `"`, `""`)
+	return fmt.Sprintf(`"%s"`, escapedTable)
+}
+
+// beginScan executes query (binding bindData.Params when any are set) via
+// GetFlightInfo/GetFlightInfoWithParams and stores
+// the resulting endpoints on bindData for duckarrow_go_scan_max_threads and
+// duckarrow_go_scan_init_local to fan out across. When Flight SQL only
+// returned a single endpoint there's nothing to parallelize, so that
+// partition is opened immediately onto bindData's own scanCursor and every
+// worker thread ends up reading through it instead of a per-thread
+// ScanThreadState.
+//
+// A flight_query bind (bindData.RawQuery != "") skips this thread-per-
+// endpoint fan-out in favor of beginParallelQuery: its SQL text is typically
+// unique per call, so there's no repeated-query benefit to the scheme the
+// way a replacement-scan table read gets, and DuckDB isn't guaranteed to
+// actually grant it the worker threads duckarrow_go_scan_max_threads asks
+// for. Fetching every endpoint itself up front keeps flight_query fast on
+// sharded backends regardless of how DuckDB schedules it.
+func beginScan(ctx context.Context, bindData *ScanBindData, query string) error {
+	if bindData.RawQuery != "" {
+		return beginParallelQuery(ctx, bindData, query)
+	}
+
+	var pq *flight.PartitionedQuery
+	var err error
+	if len(bindData.Params) > 0 {
+		pq, err = bindData.Client.GetFlightInfoWithParams(ctx, query, bindData.Params)
 	} else {
-		escapedTable := strings.ReplaceAll(bindData.TableName, `"`, `""`)
-		query = fmt.Sprintf(`SELECT %s FROM "%s"`, columnList, escapedTable)
+		pq, err = bindData.Client.GetFlightInfo(ctx, query)
 	}
+	if err != nil {
+		return fmt.Errorf("get flight info: %w", err)
+	}
+	bindData.Query = pq
+
+	if len(pq.Partitions) > 1 {
+		return nil
+	}
+
+	var partition []byte
+	if len(pq.Partitions) == 1 {
+		partition = pq.Partitions[0]
+	}
+	reader, err := bindData.Client.ReadPartition(ctx, partition)
+	if err != nil {
+		return fmt.Errorf("read partition: endpoint 0: %w", err)
+	}
+	bindData.Reader = reader
+	return nil
+}
 
-	// Execute the actual query
-	ctx := context.Background()
-	queryResult, err := bindData.Client.Query(ctx, query)
+// beginParallelQuery services a flight_query bind via
+// Client.QueryParallel/QueryParallelWithParams, which fans any Flight
+// endpoints the query produces out concurrently (bounded by
+// max_parallel_endpoints) and merges them into a single reader. The merged
+// reader is stored the same way the single-endpoint fallback above stores
+// its reader - on bindData's own scanCursor - and bindData.Query is left
+// with no partitions, so duckarrow_go_scan_max_threads reports a single
+// thread: the fan-out already happened inside the merged reader, so there's
+// nothing left for DuckDB's own worker threads to parallelize.
+func beginParallelQuery(ctx context.Context, bindData *ScanBindData, query string) error {
+	var result *flight.QueryResult
+	var err error
+	if len(bindData.Params) > 0 {
+		result, err = bindData.Client.QueryParallelWithParams(ctx, query, bindData.Params, 0)
+	} else {
+		result, err = bindData.Client.QueryParallel(ctx, query, 0)
+	}
 	if err != nil {
-		return C.CString(fmt.Sprintf("duckarrow_go_scan_init: query failed: %v", err))
+		return fmt.Errorf("parallel query: %w", err)
+	}
+
+	bindData.Reader = result.Reader
+	bindData.Query = &flight.PartitionedQuery{Schema: result.Reader.Schema(), Stmt: result.Stmt}
+	return nil
+}
+
+// duckarrow_go_scan_init_ex initializes the scan with both projection and
+// predicate pushdown. C++ calls this instead of duckarrow_go_scan_init when
+// it has extracted filters via DuckDB's ExtractFilters/ComplexFilterPushdown
+// table function hooks, passing each pushed predicate as three parallel
+// arrays (column index, operator, literal) plus an optional row limit
+// (negative means "no limit").
+//
+// Predicates this function can't safely translate (unknown operator,
+// out-of-range column, or a column type it doesn't know how to format) are
+// left untranslated and their indices written to rejectedOut, up to the
+// caller-provided capacity in rejectedCap; *rejectedCount reports how many
+// were written. DuckDB must re-evaluate those predicates itself since they
+// were not applied remotely.
+//
+//export duckarrow_go_scan_init_ex
+func duckarrow_go_scan_init_ex(
+	scanHandle C.DuckArrowScanHandle,
+	columnIDs *C.size_t, columnCount C.size_t,
+	predColumnIndices *C.size_t, predOperators **C.char, predLiterals **C.char, predicateCount C.size_t,
+	limit C.int64_t,
+	rejectedOut *C.size_t, rejectedCap C.size_t, rejectedCount *C.size_t,
+) *C.char {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if rejectedCount != nil {
+		*rejectedCount = 0
+	}
+
+	if scanHandle == nil {
+		return C.CString("duckarrow_go_scan_init_ex: nil scan handle")
+	}
+
+	handle := cgo.Handle(uintptr(scanHandle))
+	bindData, ok := handle.Value().(*ScanBindData)
+	if !ok || bindData == nil {
+		return C.CString("duckarrow_go_scan_init_ex: invalid scan handle")
+	}
+
+	count := int(columnCount)
+	projectedColumns := make([]int, count)
+	if count > 0 && columnIDs != nil {
+		ids := unsafe.Slice(columnIDs, count)
+		for i := 0; i < count; i++ {
+			projectedColumns[i] = int(ids[i])
+		}
+	}
+	bindData.ProjectedColumns = projectedColumns
+
+	var columnList string
+	if count == 0 || count == len(bindData.ColumnNames) {
+		columnList = "*"
+	} else {
+		cols := make([]string, count)
+		for i, colIdx := range projectedColumns {
+			if colIdx >= 0 && colIdx < len(bindData.ColumnNames) {
+				escapedCol := strings.ReplaceAll(bindData.ColumnNames[colIdx], `"`, `""`)
+				cols[i] = fmt.Sprintf(`"%s"`, escapedCol)
+			}
+		}
+		columnList = strings.Join(cols, ", ")
+	}
+
+	predicates := decodePredicates(predColumnIndices, predOperators, predLiterals, int(predicateCount))
+	bindData.Predicates = predicates
+	filters, rejected := translatePredicates(bindData, predicates)
+
+	if rejectedOut != nil && rejectedCap > 0 {
+		rejectedSlice := unsafe.Slice(rejectedOut, int(rejectedCap))
+		n := len(rejected)
+		if n > int(rejectedCap) {
+			n = int(rejectedCap)
+		}
+		for i := 0; i < n; i++ {
+			rejectedSlice[i] = C.size_t(rejected[i])
+		}
+		if rejectedCount != nil {
+			*rejectedCount = C.size_t(n)
+		}
 	}
 
-	bindData.Stmt = queryResult.Stmt
-	bindData.Reader = queryResult.Reader
+	query := fmt.Sprintf("SELECT %s FROM %s", columnList, scanFromClause(bindData))
+	if len(filters) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(filters, " AND "))
+	}
+	if limit >= 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, int64(limit))
+	}
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.scan_init",
+		attribute.String("uri", bindData.URI), attribute.String("schema", bindData.SchemaName),
+		attribute.String("table", bindData.TableName), attribute.Int("pushed_filters", len(filters)),
+		attribute.Int("rejected_filters", len(rejected)))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	if err := beginScan(ctx, bindData, query); err != nil {
+		opErr = err
+		return C.CString(fmt.Sprintf("duckarrow_go_scan_init_ex: %v", err))
+	}
 
 	return nil // Success
 }
 
-// duckarrow_go_scan_next scans the next chunk of data.
+// decodePredicates copies the three parallel C arrays passed to
+// duckarrow_go_scan_init_ex into Go values.
+func decodePredicates(colIndices *C.size_t, operators **C.char, literals **C.char, count int) []pushedPredicate {
+	if count <= 0 {
+		return nil
+	}
+
+	indices := unsafe.Slice(colIndices, count)
+	ops := unsafe.Slice(operators, count)
+	lits := unsafe.Slice(literals, count)
+
+	predicates := make([]pushedPredicate, count)
+	for i := 0; i < count; i++ {
+		p := pushedPredicate{ColumnIndex: int(indices[i])}
+		if ops[i] != nil {
+			p.Operator = C.GoString(ops[i])
+		}
+		if lits[i] != nil {
+			p.Literal = C.GoString(lits[i])
+		}
+		predicates[i] = p
+	}
+	return predicates
+}
+
+// translatePredicates converts pushed predicates into quoted WHERE-clause
+// fragments using bindData's column names/types to format each literal.
+// Predicates that can't be safely translated are returned by index in
+// rejected so DuckDB re-evaluates them locally.
+func translatePredicates(bindData *ScanBindData, predicates []pushedPredicate) (filters []string, rejected []int) {
+	for i, p := range predicates {
+		frag, ok := translatePredicate(bindData, p)
+		if !ok {
+			rejected = append(rejected, i)
+			continue
+		}
+		filters = append(filters, frag)
+	}
+	return filters, rejected
+}
+
+func translatePredicate(bindData *ScanBindData, p pushedPredicate) (string, bool) {
+	if p.ColumnIndex < 0 || p.ColumnIndex >= len(bindData.ColumnNames) {
+		return "", false
+	}
+	col := fmt.Sprintf(`"%s"`, strings.ReplaceAll(bindData.ColumnNames[p.ColumnIndex], `"`, `""`))
+	colType := bindData.ColumnTypes[p.ColumnIndex]
+
+	switch p.Operator {
+	case "IS NULL":
+		return fmt.Sprintf("%s IS NULL", col), true
+	case "=", "<", "<=", ">", ">=", "LIKE":
+		lit, ok := formatPredicateLiteral(colType, p.Literal)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s %s %s", col, p.Operator, lit), true
+	case "IN":
+		values := strings.Split(p.Literal, predicateListSep)
+		formatted := make([]string, len(values))
+		for i, v := range values {
+			lit, ok := formatPredicateLiteral(colType, v)
+			if !ok {
+				return "", false
+			}
+			formatted[i] = lit
+		}
+		return fmt.Sprintf("%s IN (%s)", col, strings.Join(formatted, ", ")), true
+	default:
+		return "", false
+	}
+}
+
+// formatPredicateLiteral renders a single already-extracted literal value as
+// SQL text appropriate for colType (one of the strings arrowTypeToString
+// produces): quoted and escaped for text-like types, cast for
+// date/time/decimal types so the server parses it with the right precision,
+// and passed through unquoted for plain numeric/boolean types.
+func formatPredicateLiteral(colType, literal string) (string, bool) {
+	upper := strings.ToUpper(colType)
+	switch {
+	case upper == "VARCHAR" || upper == "BLOB":
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(literal, "'", "''")), true
+	case upper == "TIMESTAMP" || upper == "DATE" || upper == "TIME":
+		return fmt.Sprintf("CAST('%s' AS %s)", strings.ReplaceAll(literal, "'", "''"), upper), true
+	case strings.HasPrefix(upper, "DECIMAL"):
+		return fmt.Sprintf("CAST(%s AS %s)", literal, upper), true
+	case upper == "BOOLEAN", upper == "BIGINT", upper == "INTEGER", upper == "SMALLINT", upper == "TINYINT",
+		upper == "UBIGINT", upper == "UINTEGER", upper == "USMALLINT", upper == "UTINYINT",
+		upper == "DOUBLE", upper == "FLOAT":
+		return literal, true
+	default:
+		return "", false
+	}
+}
+
+// columnStats is one column's min/max/null_count, read from the per-batch
+// Arrow field metadata a Flight SQL server may attach (see
+// extractColumnStats). Any subset may be present; batchSkippable only uses
+// the fields a given predicate's operator needs.
+type columnStats struct {
+	Min, Max       string
+	HasMin, HasMax bool
+	NullCount      int64
+	HasNullCount   bool
+}
+
+// metadataValue looks up key in meta, mirroring the FindKey/Values pair the
+// arrow.Metadata API exposes instead of a single get-by-key accessor.
+func metadataValue(meta arrow.Metadata, key string) (string, bool) {
+	idx := meta.FindKey(key)
+	if idx < 0 {
+		return "", false
+	}
+	return meta.Values()[idx], true
+}
+
+// extractColumnStats reads "min"/"max"/"null_count" keys off field's
+// metadata, the convention request chunk2-5 documents for Flight SQL
+// servers to ship row-group/batch statistics. ok is false when none of the
+// three keys are present, so callers know there's nothing to skip on.
+func extractColumnStats(field arrow.Field) (stats columnStats, ok bool) {
+	if v, found := metadataValue(field.Metadata, "min"); found {
+		stats.Min, stats.HasMin, ok = v, true, true
+	}
+	if v, found := metadataValue(field.Metadata, "max"); found {
+		stats.Max, stats.HasMax, ok = v, true, true
+	}
+	if v, found := metadataValue(field.Metadata, "null_count"); found {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			stats.NullCount, stats.HasNullCount, ok = n, true, true
+		}
+	}
+	return stats, ok
+}
+
+// compareTypedLiterals orders two already-extracted literal strings as
+// colType's underlying value, reusing the same type vocabulary
+// formatPredicateLiteral uses for pushed filters. ok is false when colType
+// isn't one this function knows how to order (e.g. DATE, DECIMAL, BLOB), in
+// which case callers must not use cmp to skip a batch.
+func compareTypedLiterals(colType, a, b string) (cmp int, ok bool) {
+	switch strings.ToUpper(colType) {
+	case "BIGINT", "INTEGER", "SMALLINT", "TINYINT", "UBIGINT", "UINTEGER", "USMALLINT", "UTINYINT":
+		av, errA := strconv.ParseInt(a, 10, 64)
+		bv, errB := strconv.ParseInt(b, 10, 64)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		return compareInt64(av, bv), true
+	case "DOUBLE", "FLOAT":
+		av, errA := strconv.ParseFloat(a, 64)
+		bv, errB := strconv.ParseFloat(b, 64)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		return compareFloat64(av, bv), true
+	case "VARCHAR":
+		return strings.Compare(a, b), true
+	default:
+		return 0, false
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// predicateProvablyUnsatisfiable reports whether stats rule out any row in
+// a batch matching p, for the handful of operators with a cheap range test
+// against min/max/null_count. Anything it can't evaluate (missing stats, an
+// operator without a range test, or a colType compareTypedLiterals doesn't
+// know how to order) returns false, since a batch must never be skipped on
+// a predicate this function couldn't actually check.
+func predicateProvablyUnsatisfiable(p pushedPredicate, colType string, stats columnStats) bool {
+	if p.Operator == "IS NULL" {
+		return stats.HasNullCount && stats.NullCount == 0
+	}
+
+	switch p.Operator {
+	case "=":
+		if stats.HasMin {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Min); ok && cmp < 0 {
+				return true
+			}
+		}
+		if stats.HasMax {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Max); ok && cmp > 0 {
+				return true
+			}
+		}
+	case ">":
+		if stats.HasMax {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Max); ok && cmp >= 0 {
+				return true
+			}
+		}
+	case ">=":
+		if stats.HasMax {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Max); ok && cmp > 0 {
+				return true
+			}
+		}
+	case "<":
+		if stats.HasMin {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Min); ok && cmp <= 0 {
+				return true
+			}
+		}
+	case "<=":
+		if stats.HasMin {
+			if cmp, ok := compareTypedLiterals(colType, p.Literal, stats.Min); ok && cmp < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// batchSkippable reports whether batch can be skipped entirely for
+// predicates, using whichever columns carry min/max/null_count field
+// metadata (extractColumnStats). When no batch in the stream carries any
+// stats, every call returns false and duckarrow_go_scan_next behaves
+// exactly as it did before this existed.
+//
+// predicates' ColumnIndex is a bind-time index into bindData.ColumnNames /
+// bindData.ColumnTypes (see decodePredicates), but batch only holds the
+// columns duckarrow_go_scan_init/_ex actually projected, in projectedColumns
+// order - so each predicate's column must be relocated to its position
+// within batch before indexing it, not read off batch at ColumnIndex
+// directly.
+func batchSkippable(batch arrow.RecordBatch, predicates []pushedPredicate, columnTypes []string, projectedColumns []int) bool {
+	schema := batch.Schema()
+	for _, p := range predicates {
+		if p.ColumnIndex < 0 || p.ColumnIndex >= len(columnTypes) {
+			continue
+		}
+
+		batchPos := p.ColumnIndex
+		if len(projectedColumns) > 0 {
+			batchPos = -1
+			for i, colIdx := range projectedColumns {
+				if colIdx == p.ColumnIndex {
+					batchPos = i
+					break
+				}
+			}
+			if batchPos < 0 {
+				// Predicate's column wasn't projected into this batch at
+				// all (e.g. only referenced in the WHERE clause DuckDB
+				// evaluates itself); nothing to check it against.
+				continue
+			}
+		}
+		if batchPos >= len(schema.Fields()) {
+			continue
+		}
+
+		stats, ok := extractColumnStats(schema.Field(batchPos))
+		if !ok {
+			continue
+		}
+		if predicateProvablyUnsatisfiable(p, columnTypes[p.ColumnIndex], stats) {
+			return true
+		}
+	}
+	return false
+}
+
+// duckarrow_go_scan_stats reports how many Arrow batches this scan has read
+// versus skipped via batchSkippable, so users can judge whether their
+// Flight SQL server ships enough column statistics for it to help.
+// scanHandle may be either the global scan handle or a per-thread
+// duckarrow_go_scan_init_local handle; the counters live on the shared
+// ScanBindData, so either resolves to the same totals.
+//
+//export duckarrow_go_scan_stats
+func duckarrow_go_scan_stats(scanHandle C.DuckArrowScanHandle) C.DuckArrowScanStats {
+	var stats C.DuckArrowScanStats
+
+	if scanHandle == nil {
+		return stats
+	}
+
+	handle := cgo.Handle(uintptr(scanHandle))
+	var bindData *ScanBindData
+	switch v := handle.Value().(type) {
+	case *ScanBindData:
+		bindData = v
+	case *ScanThreadState:
+		bindData = v.Bind
+	default:
+		return stats
+	}
+
+	stats.batches_read = C.int64_t(atomic.LoadInt64(&bindData.batchesRead))
+	stats.batches_skipped = C.int64_t(atomic.LoadInt64(&bindData.batchesSkipped))
+	return stats
+}
+
+// duckarrow_go_scan_max_threads reports how many DuckDB worker threads can
+// read this scan in parallel: one per Flight endpoint GetFlightInfo
+// returned, or 1 when there was only a single endpoint to begin with.
+//
+//export duckarrow_go_scan_max_threads
+func duckarrow_go_scan_max_threads(scanHandle C.DuckArrowScanHandle) C.int64_t {
+	if scanHandle == nil {
+		return 1
+	}
+
+	handle := cgo.Handle(uintptr(scanHandle))
+	bindData, ok := handle.Value().(*ScanBindData)
+	if !ok || bindData == nil || bindData.Query == nil || len(bindData.Query.Partitions) < 2 {
+		return 1
+	}
+
+	return C.int64_t(len(bindData.Query.Partitions))
+}
+
+// duckarrow_go_scan_init_local is DuckDB's per-thread local-init callback
+// for a parallel scan. Each worker thread calls this once, before its first
+// duckarrow_go_scan_next, and gets back its own scan handle wrapping
+// exactly one Flight endpoint's DoGet stream; threads claim endpoints by
+// incrementing bindData.nextPartition so concurrent calls never open the
+// same one twice. The returned handle must eventually be released with
+// duckarrow_go_scan_free_local, independently of the global scan handle.
+//
+// When duckarrow_go_scan_max_threads already reported a single thread (the
+// server only returned one endpoint), every worker thread just reuses the
+// global scan handle instead, and duckarrow_go_scan_free_local is a no-op
+// on it since the global duckarrow_go_scan_free call owns it.
+//
+//export duckarrow_go_scan_init_local
+func duckarrow_go_scan_init_local(scanHandle C.DuckArrowScanHandle) C.DuckArrowScanHandle {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if scanHandle == nil {
+		return nil
+	}
+
+	handle := cgo.Handle(uintptr(scanHandle))
+	bindData, ok := handle.Value().(*ScanBindData)
+	if !ok || bindData == nil || bindData.Query == nil || len(bindData.Query.Partitions) < 2 {
+		return scanHandle
+	}
+
+	idx := int(atomic.AddInt32(&bindData.nextPartition, 1)) - 1
+	if idx >= len(bindData.Query.Partitions) {
+		return nil
+	}
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.scan_init_local",
+		attribute.String("uri", bindData.URI), attribute.String("table", bindData.TableName), attribute.Int("partition", idx))
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	reader, err := bindData.Client.ReadPartition(ctx, bindData.Query.Partitions[idx])
+	if err != nil {
+		opErr = err
+		return nil
+	}
+
+	state := &ScanThreadState{Bind: bindData, scanCursor: scanCursor{Reader: reader}}
+	threadHandle := cgo.NewHandle(state)
+
+	bindData.threadStatesMu.Lock()
+	bindData.threadStates = append(bindData.threadStates, threadHandle)
+	bindData.threadStatesMu.Unlock()
+
+	return C.DuckArrowScanHandle(uintptr(threadHandle))
+}
+
+// duckarrow_go_scan_next scans the next chunk of data. scanHandle is either
+// the global scan handle (single-endpoint fallback) or a per-thread handle
+// from duckarrow_go_scan_init_local (parallel scan).
 // Returns the number of rows, or negative on error.
 //
 //export duckarrow_go_scan_next
@@ -314,45 +1022,74 @@ func duckarrow_go_scan_next(scanHandle C.DuckArrowScanHandle, output unsafe.Poin
 	}
 
 	handle := cgo.Handle(uintptr(scanHandle))
-	bindData, ok := handle.Value().(*ScanBindData)
-	if !ok || bindData == nil {
+	var cursor *scanCursor
+	var bindData *ScanBindData
+	var uri, tableName string
+	switch v := handle.Value().(type) {
+	case *ScanBindData:
+		cursor, bindData, uri, tableName = &v.scanCursor, v, v.URI, v.TableName
+	case *ScanThreadState:
+		cursor, bindData, uri, tableName = &v.scanCursor, v.Bind, v.Bind.URI, v.Bind.TableName
+	default:
 		return -1
 	}
 
-	if atomic.LoadInt32(&bindData.Done) == 1 {
+	_, span := tracing.StartSpan(context.Background(), "duckarrow.scan_next",
+		attribute.String("uri", uri), attribute.String("table", tableName))
+	var opErr error
+	var rowsEmitted int
+	defer func() {
+		span.SetAttributes(attribute.Int("rows_emitted", rowsEmitted))
+		tracing.EndWithError(span, opErr)
+	}()
+
+	if atomic.LoadInt32(&cursor.Done) == 1 {
 		return 0
 	}
 
-	if bindData.Reader == nil {
+	if cursor.Reader == nil {
+		opErr = fmt.Errorf("scan reader not initialized")
 		return -1
 	}
 
-	// Get next batch if needed
-	if bindData.CurrentBatch == nil || bindData.BatchPosition >= bindData.CurrentBatch.NumRows() {
+	// Get next batch if needed, skipping any batch whose own min/max/
+	// null_count field metadata proves it can't satisfy bindData.Predicates -
+	// see batchSkippable - without ever materializing it into a DuckDB
+	// chunk. A skipped batch was never Retain()'d, so there's nothing to
+	// Release(); the loop just asks the reader for the next one.
+	for cursor.CurrentBatch == nil || cursor.BatchPosition >= cursor.CurrentBatch.NumRows() {
 		// Release previous batch
-		if bindData.CurrentBatch != nil {
-			bindData.CurrentBatch.Release()
-			bindData.CurrentBatch = nil
+		if cursor.CurrentBatch != nil {
+			cursor.CurrentBatch.Release()
+			cursor.CurrentBatch = nil
 		}
 
-		if !bindData.Reader.Next() {
-			if err := bindData.Reader.Err(); err != nil {
+		if !cursor.Reader.Next() {
+			if err := cursor.Reader.Err(); err != nil {
 				// Error during scan
+				opErr = err
 				return -1
 			}
-			atomic.StoreInt32(&bindData.Done, 1)
+			atomic.StoreInt32(&cursor.Done, 1)
 			return 0
 		}
 
-		// Get new batch and retain it
-		bindData.CurrentBatch = bindData.Reader.RecordBatch()
-		bindData.CurrentBatch.Retain()
-		bindData.BatchPosition = 0
+		batch := cursor.Reader.RecordBatch()
+		if len(bindData.Predicates) > 0 && batchSkippable(batch, bindData.Predicates, bindData.ColumnTypes, bindData.ProjectedColumns) {
+			atomic.AddInt64(&bindData.batchesSkipped, 1)
+			continue
+		}
+
+		// Keep it and retain it
+		cursor.CurrentBatch = batch
+		cursor.CurrentBatch.Retain()
+		cursor.BatchPosition = 0
+		atomic.AddInt64(&bindData.batchesRead, 1)
 	}
 
 	// Calculate rows to emit (max 2048 per DuckDB chunk)
 	const maxChunkSize = 2048
-	remaining := bindData.CurrentBatch.NumRows() - bindData.BatchPosition
+	remaining := cursor.CurrentBatch.NumRows() - cursor.BatchPosition
 	rowsToEmit := int(remaining)
 	if rowsToEmit > maxChunkSize {
 		rowsToEmit = maxChunkSize
@@ -362,24 +1099,31 @@ func duckarrow_go_scan_next(scanHandle C.DuckArrowScanHandle, output unsafe.Poin
 	chunk := C.duckdb_data_chunk(output)
 
 	// Convert each column
-	numCols := int(bindData.CurrentBatch.NumCols())
+	numCols := int(cursor.CurrentBatch.NumCols())
 	for colIdx := 0; colIdx < numCols; colIdx++ {
-		arrowCol := bindData.CurrentBatch.Column(colIdx)
+		arrowCol := cursor.CurrentBatch.Column(colIdx)
 		duckVec := C.duckdb_data_chunk_get_vector(chunk, C.idx_t(colIdx))
 
-		if err := convertArrowToDuckDBVector(arrowCol, duckVec, int(bindData.BatchPosition), rowsToEmit); err != nil {
+		if err := convertArrowToDuckDBVector(arrowCol, duckVec, int(cursor.BatchPosition), rowsToEmit); err != nil {
+			opErr = err
 			return -1
 		}
 	}
 
-	bindData.BatchPosition += int64(rowsToEmit)
+	cursor.BatchPosition += int64(rowsToEmit)
 	C.duckdb_data_chunk_set_size(chunk, C.idx_t(rowsToEmit))
 
+	rowsEmitted = rowsToEmit
 	return C.int64_t(rowsToEmit)
 }
 
 // convertArrowToDuckDBVector converts Arrow column data to a DuckDB vector.
-// This is a simplified version that handles common types.
+// Fixed-width primitives whose buffer layout already matches DuckDB's (a
+// contiguous array of elemSize bytes plus a "1 = valid" bitmap) are copied
+// with copyFixedWidthBulk rather than a per-row loop; offset-encoded
+// (string/binary) and nested (list/struct/map/dictionary) types still need
+// one, since each row requires its own API call or its own recursive
+// conversion.
 func convertArrowToDuckDBVector(arrowCol arrow.Array, duckVec C.duckdb_vector, offset, count int) error {
 	// Ensure validity mask is writable
 	C.duckdb_vector_ensure_validity_writable(duckVec)
@@ -400,42 +1144,69 @@ func convertArrowToDuckDBVector(arrowCol arrow.Array, duckVec C.duckdb_vector, o
 			C.free(unsafe.Pointer(cStr))
 		}
 
-	case *array.Int64:
-		ptr := (*C.int64_t)(C.duckdb_vector_get_data(duckVec))
-		data := unsafe.Slice(ptr, count)
+	case *array.LargeString:
 		for i := 0; i < count; i++ {
 			srcIdx := offset + i
 			if col.IsNull(srcIdx) {
 				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
 				continue
 			}
-			data[i] = C.int64_t(col.Value(srcIdx))
+			val := col.Value(srcIdx)
+			cStr := C.CString(val)
+			C.duckdb_vector_assign_string_element(duckVec, C.idx_t(i), cStr)
+			C.free(unsafe.Pointer(cStr))
 		}
 
-	case *array.Int32:
-		ptr := (*C.int32_t)(C.duckdb_vector_get_data(duckVec))
-		data := unsafe.Slice(ptr, count)
+	case *array.Binary:
 		for i := 0; i < count; i++ {
 			srcIdx := offset + i
 			if col.IsNull(srcIdx) {
 				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
 				continue
 			}
-			data[i] = C.int32_t(col.Value(srcIdx))
+			assignBinaryElement(duckVec, i, col.Value(srcIdx))
 		}
 
-	case *array.Float64:
-		ptr := (*C.double)(C.duckdb_vector_get_data(duckVec))
-		data := unsafe.Slice(ptr, count)
+	case *array.LargeBinary:
 		for i := 0; i < count; i++ {
 			srcIdx := offset + i
 			if col.IsNull(srcIdx) {
 				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
 				continue
 			}
-			data[i] = C.double(col.Value(srcIdx))
+			assignBinaryElement(duckVec, i, col.Value(srcIdx))
 		}
 
+	case *array.Int8:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 1, offset, count)
+
+	case *array.Int16:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 2, offset, count)
+
+	case *array.Int32:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 4, offset, count)
+
+	case *array.Int64:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 8, offset, count)
+
+	case *array.Uint8:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 1, offset, count)
+
+	case *array.Uint16:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 2, offset, count)
+
+	case *array.Uint32:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 4, offset, count)
+
+	case *array.Uint64:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 8, offset, count)
+
+	case *array.Float32:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 4, offset, count)
+
+	case *array.Float64:
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 8, offset, count)
+
 	case *array.Boolean:
 		ptr := (*C.uint8_t)(C.duckdb_vector_get_data(duckVec))
 		data := unsafe.Slice(ptr, count)
@@ -452,6 +1223,108 @@ func convertArrowToDuckDBVector(arrowCol arrow.Array, duckVec C.duckdb_vector, o
 			}
 		}
 
+	case *array.Date32:
+		// arrow.Date32 is already days-since-epoch, the same encoding
+		// DuckDB's DATE vectors use, so this is a plain fixed-width copy.
+		copyFixedWidthBulk(duckVec, validity, col.Data().Buffers()[1].Bytes(), col.NullBitmapBytes(), 4, offset, count)
+
+	case *array.Date64:
+		// arrow.Date64 is milliseconds-since-epoch; DuckDB DATE wants days.
+		ptr := (*C.int32_t)(C.duckdb_vector_get_data(duckVec))
+		data := unsafe.Slice(ptr, count)
+		for i := 0; i < count; i++ {
+			srcIdx := offset + i
+			if col.IsNull(srcIdx) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+				continue
+			}
+			data[i] = C.int32_t(int64(col.Value(srcIdx)) / 86400000)
+		}
+
+	case *array.Time32:
+		unit := col.DataType().(*arrow.Time32Type).Unit
+		ptr := (*C.int64_t)(C.duckdb_vector_get_data(duckVec))
+		data := unsafe.Slice(ptr, count)
+		for i := 0; i < count; i++ {
+			srcIdx := offset + i
+			if col.IsNull(srcIdx) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+				continue
+			}
+			data[i] = C.int64_t(timeToMicros(int64(col.Value(srcIdx)), unit))
+		}
+
+	case *array.Time64:
+		unit := col.DataType().(*arrow.Time64Type).Unit
+		ptr := (*C.int64_t)(C.duckdb_vector_get_data(duckVec))
+		data := unsafe.Slice(ptr, count)
+		for i := 0; i < count; i++ {
+			srcIdx := offset + i
+			if col.IsNull(srcIdx) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+				continue
+			}
+			data[i] = C.int64_t(timeToMicros(int64(col.Value(srcIdx)), unit))
+		}
+
+	case *array.Timestamp:
+		// TIMESTAMP and TIMESTAMP WITH TIME ZONE share the same physical
+		// microseconds-since-epoch layout; arrowTypeToString is what tells
+		// DuckDB which logical type applies, so both write identically here.
+		unit := col.DataType().(*arrow.TimestampType).Unit
+		ptr := (*C.int64_t)(C.duckdb_vector_get_data(duckVec))
+		data := unsafe.Slice(ptr, count)
+		for i := 0; i < count; i++ {
+			srcIdx := offset + i
+			if col.IsNull(srcIdx) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+				continue
+			}
+			data[i] = C.int64_t(timeToMicros(int64(col.Value(srcIdx)), unit))
+		}
+
+	case *array.Decimal128:
+		dt := col.DataType().(*arrow.Decimal128Type)
+		for i := 0; i < count; i++ {
+			srcIdx := offset + i
+			if col.IsNull(srcIdx) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+				continue
+			}
+			assignDecimal128Element(duckVec, i, dt.Precision, col.Value(srcIdx))
+		}
+
+	case *array.List:
+		if err := writeListVector(col, duckVec, validity, offset, count); err != nil {
+			return err
+		}
+
+	case *array.Map:
+		if err := writeListVector(col.List, duckVec, validity, offset, count); err != nil {
+			return err
+		}
+
+	case *array.Struct:
+		for i := 0; i < count; i++ {
+			if col.IsNull(offset + i) {
+				C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+			}
+		}
+		for f := 0; f < col.NumField(); f++ {
+			childVec := C.duckdb_struct_vector_get_child(duckVec, C.idx_t(f))
+			if err := convertArrowToDuckDBVector(col.Field(f), childVec, offset, count); err != nil {
+				return err
+			}
+		}
+
+	case *array.Dictionary:
+		decoded, err := decodeDictionarySlice(col, offset, count)
+		if err != nil {
+			return err
+		}
+		defer decoded.Release()
+		return convertArrowToDuckDBVector(decoded, duckVec, 0, count)
+
 	default:
 		// Fallback: convert to string
 		for i := 0; i < count; i++ {
@@ -475,7 +1348,189 @@ func convertArrowToDuckDBVector(arrowCol arrow.Array, duckVec C.duckdb_vector, o
 	return nil
 }
 
-// duckarrow_go_scan_free frees resources associated with a scan handle.
+// copyFixedWidthBulk memcpy's a fixed-width Arrow value buffer directly
+// into a DuckDB vector's backing storage, rather than converting one value
+// at a time: Arrow and DuckDB agree on a contiguous little-endian array of
+// elemSize bytes per value for every type this is called for. The validity
+// bitmap still needs a per-row pass since Arrow's bitmap may start at a
+// non-byte-aligned bit offset, but that's a cheap bit test, not a value
+// conversion.
+func copyFixedWidthBulk(duckVec C.duckdb_vector, validity C.duckdb_validity, data, bitmap []byte, elemSize, offset, count int) {
+	if count == 0 {
+		return
+	}
+	dst := C.duckdb_vector_get_data(duckVec)
+	src := unsafe.Pointer(&data[offset*elemSize])
+	C.memcpy(dst, src, C.size_t(count*elemSize))
+
+	if bitmap == nil {
+		return
+	}
+	for i := 0; i < count; i++ {
+		srcIdx := offset + i
+		if bitmap[srcIdx/8]&(1<<uint(srcIdx%8)) == 0 {
+			C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+		}
+	}
+}
+
+// timeToMicros scales an Arrow TIME/TIMESTAMP value in unit to the
+// microseconds DuckDB's TIME/TIMESTAMP vectors store.
+func timeToMicros(v int64, unit arrow.TimeUnit) int64 {
+	switch unit {
+	case arrow.Second:
+		return v * 1_000_000
+	case arrow.Millisecond:
+		return v * 1_000
+	case arrow.Nanosecond:
+		return v / 1_000
+	default: // arrow.Microsecond
+		return v
+	}
+}
+
+// assignBinaryElement assigns a BLOB element from a byte slice that, unlike
+// string elements, isn't NUL-terminated and may itself contain NUL bytes.
+func assignBinaryElement(duckVec C.duckdb_vector, row int, val []byte) {
+	var ptr *C.char
+	if len(val) > 0 {
+		ptr = (*C.char)(unsafe.Pointer(&val[0]))
+	}
+	C.duckdb_vector_assign_string_element_len(duckVec, C.idx_t(row), ptr, C.idx_t(len(val)))
+}
+
+// assignDecimal128Element writes one DECIMAL value into duckVec, using
+// whichever physical integer width DuckDB selects for the column's
+// precision (INT16/32/64 for precision up to 18, INT128/hugeint above
+// that) rather than always writing a 128-bit value.
+func assignDecimal128Element(duckVec C.duckdb_vector, row int, precision int32, v decimal128.Num) {
+	switch {
+	case precision <= 4:
+		ptr := (*C.int16_t)(C.duckdb_vector_get_data(duckVec))
+		unsafe.Slice(ptr, row+1)[row] = C.int16_t(v.LowBits())
+	case precision <= 9:
+		ptr := (*C.int32_t)(C.duckdb_vector_get_data(duckVec))
+		unsafe.Slice(ptr, row+1)[row] = C.int32_t(v.LowBits())
+	case precision <= 18:
+		ptr := (*C.int64_t)(C.duckdb_vector_get_data(duckVec))
+		unsafe.Slice(ptr, row+1)[row] = C.int64_t(v.LowBits())
+	default:
+		ptr := (*C.duckdb_hugeint)(C.duckdb_vector_get_data(duckVec))
+		unsafe.Slice(ptr, row+1)[row] = C.duckdb_hugeint{
+			lower: C.uint64_t(v.LowBits()),
+			upper: C.int64_t(v.HighBits()),
+		}
+	}
+}
+
+// writeListVector converts a LIST- (or MAP-, which Arrow and DuckDB both
+// represent as a list of key/value structs) backed Arrow array into
+// duckVec: one duckdb_list_entry per output row pointing into the child
+// vector, then a single recursive call that flushes every row's elements
+// into the child vector at once. Arrow's offsets buffer is monotonic even
+// across null/empty rows, so the flattened child range for this batch is
+// always contiguous and can be copied in one shot.
+func writeListVector(col *array.List, duckVec C.duckdb_vector, validity C.duckdb_validity, offset, count int) error {
+	if count == 0 {
+		return nil
+	}
+
+	entryPtr := (*C.duckdb_list_entry)(C.duckdb_vector_get_data(duckVec))
+	entries := unsafe.Slice(entryPtr, count)
+
+	firstChildOffset, lastChildOffset := -1, -1
+	for i := 0; i < count; i++ {
+		srcIdx := offset + i
+		if col.IsNull(srcIdx) {
+			C.duckdb_validity_set_row_invalid(validity, C.idx_t(i))
+		}
+		start := col.Offsets()[srcIdx]
+		end := col.Offsets()[srcIdx+1]
+		if firstChildOffset == -1 {
+			firstChildOffset = int(start)
+		}
+		lastChildOffset = int(end)
+		entries[i] = C.duckdb_list_entry{
+			offset: C.idx_t(int(start) - firstChildOffset),
+			length: C.idx_t(end - start),
+		}
+	}
+
+	flattenedCount := 0
+	if firstChildOffset != -1 {
+		flattenedCount = lastChildOffset - firstChildOffset
+	}
+
+	childVec := C.duckdb_list_vector_get_child(duckVec)
+	C.duckdb_list_vector_reserve(childVec, C.idx_t(flattenedCount))
+	C.duckdb_list_vector_set_size(duckVec, C.idx_t(flattenedCount))
+
+	if flattenedCount == 0 {
+		return nil
+	}
+	return convertArrowToDuckDBVector(col.ListValues(), childVec, firstChildOffset, flattenedCount)
+}
+
+// decodeDictionarySlice expands the [offset, offset+count) slice of a
+// dictionary-encoded column into a plain array of its value type (via
+// AppendValueFromString, the generic textual round-trip every Arrow
+// builder supports), so the rest of convertArrowToDuckDBVector can handle
+// it like any other column regardless of what type is dictionary-encoded.
+func decodeDictionarySlice(col *array.Dictionary, offset, count int) (arrow.Array, error) {
+	dict := col.Dictionary()
+	bldr := array.NewBuilder(memory.NewGoAllocator(), dict.DataType())
+	defer bldr.Release()
+
+	for i := 0; i < count; i++ {
+		srcIdx := offset + i
+		if col.IsNull(srcIdx) {
+			bldr.AppendNull()
+			continue
+		}
+		idx := col.GetValueIndex(srcIdx)
+		if err := bldr.AppendValueFromString(dict.ValueStr(idx)); err != nil {
+			return nil, fmt.Errorf("decode dictionary value: %w", err)
+		}
+	}
+	return bldr.NewArray(), nil
+}
+
+// duckarrow_go_scan_free_local releases one worker thread's partition
+// reader, as returned by duckarrow_go_scan_init_local. Safe to call
+// concurrently for distinct thread handles of the same scan. A no-op when
+// scanHandle is actually the global scan handle, which the single-endpoint
+// fallback in duckarrow_go_scan_init_local hands out instead of a
+// ScanThreadState and which duckarrow_go_scan_free owns.
+//
+//export duckarrow_go_scan_free_local
+func duckarrow_go_scan_free_local(scanHandle C.DuckArrowScanHandle) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if scanHandle == nil {
+		return
+	}
+
+	handle := cgo.Handle(uintptr(scanHandle))
+	state, ok := handle.Value().(*ScanThreadState)
+	if !ok || state == nil {
+		return
+	}
+
+	if state.CurrentBatch != nil {
+		state.CurrentBatch.Release()
+	}
+	if state.Reader != nil {
+		state.Reader.Release()
+	}
+	state.Bind.forgetThreadHandle(handle)
+	handle.Delete()
+}
+
+// duckarrow_go_scan_free frees resources associated with the global scan
+// handle, including any per-thread ScanThreadState a parallel scan's
+// worker threads never released themselves via duckarrow_go_scan_free_local
+// (e.g. because the scan errored out before every thread ran).
 //
 //export duckarrow_go_scan_free
 func duckarrow_go_scan_free(scanHandle C.DuckArrowScanHandle) {
@@ -489,7 +1544,25 @@ func duckarrow_go_scan_free(scanHandle C.DuckArrowScanHandle) {
 	handle := cgo.Handle(uintptr(scanHandle))
 	bindData, ok := handle.Value().(*ScanBindData)
 	if ok && bindData != nil {
-		// Release current batch
+		// Release any thread states no one ever freed individually.
+		bindData.threadStatesMu.Lock()
+		remaining := bindData.threadStates
+		bindData.threadStates = nil
+		bindData.threadStatesMu.Unlock()
+
+		for _, th := range remaining {
+			if state, ok := th.Value().(*ScanThreadState); ok && state != nil {
+				if state.CurrentBatch != nil {
+					state.CurrentBatch.Release()
+				}
+				if state.Reader != nil {
+					state.Reader.Release()
+				}
+			}
+			th.Delete()
+		}
+
+		// Release current batch (single-endpoint fallback path)
 		if bindData.CurrentBatch != nil {
 			bindData.CurrentBatch.Release()
 		}
@@ -498,14 +1571,14 @@ func duckarrow_go_scan_free(scanHandle C.DuckArrowScanHandle) {
 		if bindData.Reader != nil {
 			bindData.Reader.Release()
 		}
-		if bindData.Stmt != nil {
-			bindData.Stmt.Close()
+		if bindData.Query != nil && bindData.Query.Stmt != nil {
+			bindData.Query.Stmt.Close()
 		}
 
 		// Clean up connection
 		if bindData.Client != nil {
 			if bindData.IsPooled {
-				flight.ReleaseConnection(bindData.Config)
+				flight.ReleaseConnection(bindData.Lease)
 			} else {
 				bindData.Client.Close()
 			}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+func TestMetadataValue(t *testing.T) {
+	meta := arrow.NewMetadata([]string{"min", "max"}, []string{"1", "100"})
+
+	if v, ok := metadataValue(meta, "min"); !ok || v != "1" {
+		t.Errorf(`metadataValue(meta, "min") = (%q, %v), want ("1", true)`, v, ok)
+	}
+	if _, ok := metadataValue(meta, "null_count"); ok {
+		t.Error(`metadataValue(meta, "null_count") found a value that isn't there`)
+	}
+}
+
+func TestExtractColumnStats(t *testing.T) {
+	withStats := arrow.Field{
+		Name:     "id",
+		Type:     arrow.PrimitiveTypes.Int64,
+		Metadata: arrow.NewMetadata([]string{"min", "max", "null_count"}, []string{"1", "100", "0"}),
+	}
+	stats, ok := extractColumnStats(withStats)
+	if !ok {
+		t.Fatal("extractColumnStats: expected ok=true for field with stats metadata")
+	}
+	if stats.Min != "1" || stats.Max != "100" || stats.NullCount != 0 {
+		t.Errorf("extractColumnStats = %+v, want Min=1 Max=100 NullCount=0", stats)
+	}
+
+	bare := arrow.Field{Name: "id", Type: arrow.PrimitiveTypes.Int64}
+	if _, ok := extractColumnStats(bare); ok {
+		t.Error("extractColumnStats: expected ok=false for field with no stats metadata")
+	}
+}
+
+func TestCompareTypedLiterals(t *testing.T) {
+	tests := []struct {
+		colType string
+		a, b    string
+		wantCmp int
+		wantOK  bool
+	}{
+		{"BIGINT", "5", "10", -1, true},
+		{"BIGINT", "10", "5", 1, true},
+		{"BIGINT", "5", "5", 0, true},
+		{"DOUBLE", "1.5", "1.5", 0, true},
+		{"VARCHAR", "abc", "abd", -1, true},
+		{"BIGINT", "not-a-number", "5", 0, false},
+		{"DATE", "2024-01-01", "2024-01-02", 0, false},
+	}
+
+	for _, tt := range tests {
+		cmp, ok := compareTypedLiterals(tt.colType, tt.a, tt.b)
+		if ok != tt.wantOK {
+			t.Errorf("compareTypedLiterals(%q, %q, %q) ok = %v, want %v", tt.colType, tt.a, tt.b, ok, tt.wantOK)
+			continue
+		}
+		if ok && cmp != tt.wantCmp {
+			t.Errorf("compareTypedLiterals(%q, %q, %q) cmp = %d, want %d", tt.colType, tt.a, tt.b, cmp, tt.wantCmp)
+		}
+	}
+}
+
+func TestBatchSkippable(t *testing.T) {
+	statsField := func(name string, min, max string) arrow.Field {
+		return arrow.Field{
+			Name:     name,
+			Type:     arrow.PrimitiveTypes.Int64,
+			Metadata: arrow.NewMetadata([]string{"min", "max"}, []string{min, max}),
+		}
+	}
+
+	t.Run("projected columns relocate the predicate's column index", func(t *testing.T) {
+		// Original table is (a, b, c); only "b" (original index 1) was
+		// projected, landing at batch position 0. The predicate targets "a"
+		// (original index 0), which isn't in the batch at all.
+		schema := arrow.NewSchema([]arrow.Field{statsField("b", "1", "100")}, nil)
+		batch := array.NewRecordBuilder(memory.DefaultAllocator, schema).NewRecord()
+		defer batch.Release()
+
+		predicates := []pushedPredicate{{ColumnIndex: 0, Operator: "=", Literal: "0"}}
+		columnTypes := []string{"BIGINT", "BIGINT", "BIGINT"}
+		projectedColumns := []int{1}
+
+		if batchSkippable(batch, predicates, columnTypes, projectedColumns) {
+			t.Error("batchSkippable skipped the batch based on a column that wasn't projected into it")
+		}
+	})
+
+	t.Run("projected column is located and used to skip", func(t *testing.T) {
+		// "b" (original index 1) is projected to batch position 0, and its
+		// stats prove the predicate on "b" can't match.
+		schema := arrow.NewSchema([]arrow.Field{statsField("b", "1", "100")}, nil)
+		batch := array.NewRecordBuilder(memory.DefaultAllocator, schema).NewRecord()
+		defer batch.Release()
+
+		predicates := []pushedPredicate{{ColumnIndex: 1, Operator: "=", Literal: "0"}}
+		columnTypes := []string{"BIGINT", "BIGINT", "BIGINT"}
+		projectedColumns := []int{1}
+
+		if !batchSkippable(batch, predicates, columnTypes, projectedColumns) {
+			t.Error("batchSkippable did not skip a batch its projected column's stats rule out")
+		}
+	})
+
+	t.Run("no projection (select star) uses the predicate's index directly", func(t *testing.T) {
+		schema := arrow.NewSchema([]arrow.Field{statsField("a", "1", "100")}, nil)
+		batch := array.NewRecordBuilder(memory.DefaultAllocator, schema).NewRecord()
+		defer batch.Release()
+
+		predicates := []pushedPredicate{{ColumnIndex: 0, Operator: "=", Literal: "0"}}
+		columnTypes := []string{"BIGINT"}
+
+		if !batchSkippable(batch, predicates, columnTypes, nil) {
+			t.Error("batchSkippable did not skip a batch its stats rule out under select-star")
+		}
+	})
+}
+
+func TestPredicateProvablyUnsatisfiable(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     pushedPredicate
+		stats columnStats
+		want  bool
+	}{
+		{
+			name:  "equality below min is unsatisfiable",
+			p:     pushedPredicate{Operator: "=", Literal: "0"},
+			stats: columnStats{Min: "1", HasMin: true, Max: "100", HasMax: true},
+			want:  true,
+		},
+		{
+			name:  "equality above max is unsatisfiable",
+			p:     pushedPredicate{Operator: "=", Literal: "200"},
+			stats: columnStats{Min: "1", HasMin: true, Max: "100", HasMax: true},
+			want:  true,
+		},
+		{
+			name:  "equality within range is satisfiable",
+			p:     pushedPredicate{Operator: "=", Literal: "50"},
+			stats: columnStats{Min: "1", HasMin: true, Max: "100", HasMax: true},
+			want:  false,
+		},
+		{
+			name:  "greater-than beyond max is unsatisfiable",
+			p:     pushedPredicate{Operator: ">", Literal: "100"},
+			stats: columnStats{Max: "100", HasMax: true},
+			want:  true,
+		},
+		{
+			name:  "less-than below min is unsatisfiable",
+			p:     pushedPredicate{Operator: "<", Literal: "1"},
+			stats: columnStats{Min: "1", HasMin: true},
+			want:  true,
+		},
+		{
+			name:  "is null with zero null count is unsatisfiable",
+			p:     pushedPredicate{Operator: "IS NULL"},
+			stats: columnStats{NullCount: 0, HasNullCount: true},
+			want:  true,
+		},
+		{
+			name:  "missing stats never skips",
+			p:     pushedPredicate{Operator: "="},
+			stats: columnStats{},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := predicateProvablyUnsatisfiable(tt.p, "BIGINT", tt.stats); got != tt.want {
+				t.Errorf("predicateProvablyUnsatisfiable(%+v, BIGINT, %+v) = %v, want %v", tt.p, tt.stats, got, tt.want)
+			}
+		})
+	}
+}
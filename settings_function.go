@@ -0,0 +1,275 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_configure_option_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"context"
+	"duckdb"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"main/internal/flight"
+	"main/internal/tracing"
+	"main/internal/validation"
+)
+
+// applyDuckArrowOption applies a single tunable key/value pair to the
+// extension's global runtime settings. Unknown keys are rejected so typos
+// surface immediately instead of silently no-opping.
+func applyDuckArrowOption(key, value string) error {
+	switch key {
+	case "pool_health_check_interval":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			// Also accept a bare number of seconds for convenience.
+			secs, numErr := strconv.Atoi(value)
+			if numErr != nil {
+				return fmt.Errorf("invalid duration %q: %w", value, err)
+			}
+			d = time.Duration(secs) * time.Second
+		}
+		flight.SetPoolHealthCheckInterval(d)
+	case "pool_max_idle":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			secs, numErr := strconv.Atoi(value)
+			if numErr != nil {
+				return fmt.Errorf("invalid duration %q: %w", value, err)
+			}
+			d = time.Duration(secs) * time.Second
+		}
+		flight.SetPoolMaxIdle(d)
+	case "max_streams_per_conn":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetPoolMaxStreamsPerConn(n)
+	case "pool_max_idle_conns":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetPoolMaxIdleConns(n)
+	case "pool_max_lifetime":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			secs, numErr := strconv.Atoi(value)
+			if numErr != nil {
+				return fmt.Errorf("invalid duration %q: %w", value, err)
+			}
+			d = time.Duration(secs) * time.Second
+		}
+		flight.SetPoolMaxLifetime(d)
+	case "prepared_cache_size":
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetPreparedCacheSize(size)
+	case "prepared_cache_enabled":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q: %w", value, err)
+		}
+		flight.SetPreparedCacheEnabled(enabled)
+	case "speculative_delay_ms":
+		ms, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetSpeculativeDelay(time.Duration(ms) * time.Millisecond)
+	case "speculative_max_attempts":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetSpeculativeMaxAttempts(n)
+	case "max_parallel_endpoints":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetMaxParallelEndpoints(n)
+	case "retry_enabled":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q: %w", value, err)
+		}
+		flight.SetRetryEnabled(enabled)
+	case "retry_max_attempts":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetRetryMaxAttempts(n)
+	case "retry_base_ms":
+		ms, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetRetryBaseDelay(time.Duration(ms) * time.Millisecond)
+	case "retry_cap_ms":
+		ms, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", value, err)
+		}
+		flight.SetRetryCapDelay(time.Duration(ms) * time.Millisecond)
+	case "otel_enabled":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q: %w", value, err)
+		}
+		tracing.SetEnabled(enabled)
+	case "otel_endpoint":
+		if err := tracing.Configure(context.Background(), value); err != nil {
+			return fmt.Errorf("configure OTLP exporter: %w", err)
+		}
+	case "skip_policy_profile":
+		profile := validation.DefaultProfile(value)
+		switch profile {
+		case validation.ProfileDuckDB, validation.ProfilePostgres, validation.ProfileSQLite, validation.ProfileMotherDuck, validation.ProfileNone:
+		default:
+			return fmt.Errorf("unknown skip_policy_profile %q", value)
+		}
+		policy := *validation.DefaultSkipPolicy()
+		policy.DefaultProfile = profile
+		validation.SetDefaultSkipPolicy(&policy)
+	case "skip_policy_add_exact_name":
+		policy := *validation.DefaultSkipPolicy()
+		policy.ExactNames = append(append([]string{}, policy.ExactNames...), value)
+		validation.SetDefaultSkipPolicy(&policy)
+	case "skip_policy_add_prefix":
+		policy := *validation.DefaultSkipPolicy()
+		policy.Prefixes = append(append([]string{}, policy.Prefixes...), value)
+		validation.SetDefaultSkipPolicy(&policy)
+	case "skip_policy_add_suffix":
+		policy := *validation.DefaultSkipPolicy()
+		policy.Suffixes = append(append([]string{}, policy.Suffixes...), value)
+		validation.SetDefaultSkipPolicy(&policy)
+	case "skip_policy_add_regex":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", value, err)
+		}
+		policy := *validation.DefaultSkipPolicy()
+		policy.Regexes = append(append([]*regexp.Regexp{}, policy.Regexes...), re)
+		validation.SetDefaultSkipPolicy(&policy)
+	case "skip_policy_reset":
+		validation.SetDefaultSkipPolicy(nil)
+	default:
+		return fmt.Errorf("unknown option %q", key)
+	}
+	return nil
+}
+
+// duckarrow_configure_option_callback is the scalar function callback for
+// duckarrow_configure_option(key, value). It applies runtime tunables such as
+// the connection pool's health-check interval without requiring a restart.
+//
+//export duckarrow_configure_option_callback
+func duckarrow_configure_option_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	inputSize := C.duckdb_data_chunk_get_size(input)
+	if inputSize == 0 {
+		return
+	}
+	if inputSize > maxDuckDBChunkSize {
+		setOptionError(info, "input chunk size exceeds maximum")
+		return
+	}
+
+	keyVec := C.duckdb_data_chunk_get_vector(input, 0)
+	valueVec := C.duckdb_data_chunk_get_vector(input, 1)
+	if keyVec == nil || valueVec == nil {
+		setOptionError(info, "failed to get input vectors")
+		return
+	}
+
+	keyDataPtr := C.duckdb_vector_get_data(keyVec)
+	valueDataPtr := C.duckdb_vector_get_data(valueVec)
+	if keyDataPtr == nil || valueDataPtr == nil {
+		setOptionError(info, "failed to get input data")
+		return
+	}
+
+	for i := C.idx_t(0); i < inputSize; i++ {
+		key, err := extractString(keyDataPtr, i)
+		if err != nil {
+			setOptionError(info, "failed to read key: "+err.Error())
+			return
+		}
+		value, err := extractString(valueDataPtr, i)
+		if err != nil {
+			setOptionError(info, "failed to read value: "+err.Error())
+			return
+		}
+
+		if err := applyDuckArrowOption(key, value); err != nil {
+			setOptionError(info, err.Error())
+			return
+		}
+
+		duckdb.AssignStringToVector(duckdb.Vector{Ptr: unsafe.Pointer(output)}, int(i), "DuckArrow option applied successfully")
+	}
+}
+
+// setOptionError is a helper to set an error on the configure_option function
+// with consistent formatting.
+func setOptionError(info C.duckdb_function_info, msg string) {
+	errMsg := C.CString("duckarrow_configure_option: " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// RegisterDuckArrowConfigureOptionFunction registers the
+// duckarrow_configure_option(key, value) scalar function, used for runtime
+// tunables that don't fit the connection-parameter signature of
+// duckarrow_configure (e.g. pool health-check interval).
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_configure_option('pool_health_check_interval', '30s');
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowConfigureOptionFunction(conn duckdb.Connection) duckdb.State {
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	name := C.CString("duckarrow_configure_option")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	varcharType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // key
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // value
+	C.duckdb_destroy_logical_type(&varcharType)
+
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_configure_option_callback))
+
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
@@ -0,0 +1,113 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declaration of Go callback
+void duckarrow_set_trace_context_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"duckdb"
+	"runtime"
+	"unsafe"
+
+	"main/internal/tracing"
+)
+
+// duckarrow_set_trace_context_callback is the scalar function callback for
+// duckarrow_set_trace_context(traceparent). It stores the W3C traceparent so
+// subsequent Flight RPCs opened from CGO callbacks are linked into the
+// caller's trace. Passing an empty string clears it.
+//
+//export duckarrow_set_trace_context_callback
+func duckarrow_set_trace_context_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	inputSize := C.duckdb_data_chunk_get_size(input)
+	if inputSize == 0 {
+		return
+	}
+	if inputSize > maxDuckDBChunkSize {
+		setTraceContextError(info, "input chunk size exceeds maximum")
+		return
+	}
+
+	traceparentVec := C.duckdb_data_chunk_get_vector(input, 0)
+	if traceparentVec == nil {
+		setTraceContextError(info, "failed to get input vector")
+		return
+	}
+
+	traceparentDataPtr := C.duckdb_vector_get_data(traceparentVec)
+	if traceparentDataPtr == nil {
+		setTraceContextError(info, "failed to get input data")
+		return
+	}
+	traceparentValidity := C.duckdb_vector_get_validity(traceparentVec)
+
+	for i := C.idx_t(0); i < inputSize; i++ {
+		var traceparent string
+		if traceparentValidity == nil || rowIsValid(traceparentValidity, uint64(i), uint64(inputSize)) {
+			var err error
+			traceparent, err = extractString(traceparentDataPtr, i)
+			if err != nil {
+				setTraceContextError(info, "failed to read traceparent: "+err.Error())
+				return
+			}
+		}
+
+		tracing.SetTraceParent(traceparent)
+
+		duckdb.AssignStringToVector(duckdb.Vector{Ptr: unsafe.Pointer(output)}, int(i), "DuckArrow trace context set successfully")
+	}
+}
+
+// setTraceContextError is a helper to set an error on the
+// set_trace_context function with consistent formatting.
+func setTraceContextError(info C.duckdb_function_info, msg string) {
+	errMsg := C.CString("duckarrow_set_trace_context: " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// RegisterDuckArrowSetTraceContextFunction registers the
+// duckarrow_set_trace_context(traceparent) scalar function, which lets a SQL
+// session inject a W3C traceparent so the Flight RPCs duckarrow issues on
+// its behalf become children of the caller's own trace.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_set_trace_context('00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01');
+//
+// Parameters:
+//   - conn: Active DuckDB connection for function registration
+//
+// Returns:
+//   - duckdb.STATE_OK on success, duckdb.STATE_ERROR on failure
+func RegisterDuckArrowSetTraceContextFunction(conn duckdb.Connection) duckdb.State {
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	name := C.CString("duckarrow_set_trace_context")
+	defer C.free(unsafe.Pointer(name))
+	C.duckdb_scalar_function_set_name(scalarFunc, name)
+
+	varcharType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_add_parameter(scalarFunc, varcharType) // traceparent
+	C.duckdb_destroy_logical_type(&varcharType)
+
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	C.duckdb_scalar_function_set_function(scalarFunc,
+		C.duckdb_scalar_function_t(C.duckarrow_set_trace_context_callback))
+
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
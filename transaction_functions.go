@@ -0,0 +1,248 @@
+package main
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/duckdb-go-api -DDUCKDB_API_EXCLUDE_FUNCTIONS=1
+#include <stdlib.h>
+#include <duckdb.h>
+#include <duckdb_go_extension.h>
+
+// Forward declarations of Go callbacks
+void duckarrow_begin_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+void duckarrow_commit_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+void duckarrow_rollback_callback(duckdb_function_info info, duckdb_data_chunk input, duckdb_vector output);
+*/
+import "C"
+import (
+	"context"
+	"duckdb"
+	"errors"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"main/internal/flight"
+	"main/internal/tracing"
+)
+
+// currentThreadID identifies the OS thread the calling goroutine is pinned
+// to. duckarrow_begin/duckarrow_execute/duckarrow_commit/duckarrow_rollback
+// all call runtime.LockOSThread() before reading it, and DuckDB invokes a
+// given scalar function consistently from the same worker thread for the
+// life of a session, so this is a stable key for "this DuckDB session" as
+// seen by those four duckarrow_* functions.
+//
+// Linux-only: syscall.Gettid has no equivalent in the syscall package on
+// other platforms.
+func currentThreadID() int {
+	return syscall.Gettid()
+}
+
+// setTxError sets an error on a transaction scalar function (duckarrow_begin,
+// duckarrow_commit, duckarrow_rollback) with consistent "<fn>: <msg>" formatting.
+func setTxError(info C.duckdb_function_info, fn, msg string) {
+	errMsg := C.CString(fn + ": " + msg)
+	C.duckdb_scalar_function_set_error(info, errMsg)
+	C.free(unsafe.Pointer(errMsg))
+}
+
+// assignTxResult writes msg into every row of a transaction scalar
+// function's VARCHAR output.
+func assignTxResult(output C.duckdb_vector, inputSize int, msg string) {
+	msgCStr := C.CString(msg)
+	defer C.free(unsafe.Pointer(msgCStr))
+	for i := 0; i < inputSize; i++ {
+		C.duckdb_vector_assign_string_element(output, C.idx_t(i), msgCStr)
+	}
+}
+
+// duckarrow_begin_callback is the scalar function callback for
+// duckarrow_begin(). It borrows a connection from the pool, takes it out of
+// autocommit mode, and pins it to the calling OS thread so subsequent
+// duckarrow_execute calls on the same thread reuse it instead of each
+// borrowing and releasing their own connection, until duckarrow_commit or
+// duckarrow_rollback ends the transaction.
+//
+//export duckarrow_begin_callback
+func duckarrow_begin_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.begin")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	inputSize := int(C.duckdb_data_chunk_get_size(input))
+	if inputSize == 0 {
+		return
+	}
+
+	threadID := currentThreadID()
+	if _, open := flight.PinnedTransaction(threadID); open {
+		setTxError(info, "duckarrow_begin", "a transaction is already open on this connection")
+		return
+	}
+
+	cfg := GetDuckArrowProfileFullConfig(defaultProfileName)
+	if cfg.URI == "" {
+		opErr = errors.New("not configured")
+		setTxError(info, "duckarrow_begin", "not configured - call duckarrow_configure() first")
+		return
+	}
+	span.SetAttributes(attribute.String("uri", cfg.URI))
+
+	connResult, err := flight.GetConnection(ctx, cfg)
+	if err != nil {
+		opErr = err
+		setTxError(info, "duckarrow_begin", "connection failed: "+err.Error())
+		return
+	}
+
+	if err := connResult.Client.BeginTransaction(ctx); err != nil {
+		opErr = err
+		if connResult.IsPooled {
+			flight.ReleaseConnection(connResult.Lease)
+		} else {
+			connResult.Client.Close()
+		}
+		setTxError(info, "duckarrow_begin", "begin failed: "+err.Error())
+		return
+	}
+
+	flight.PinTransaction(threadID, connResult)
+	assignTxResult(output, inputSize, "transaction started")
+}
+
+// duckarrow_commit_callback is the scalar function callback for
+// duckarrow_commit(). It commits the transaction pinned to the calling OS
+// thread, restores autocommit mode, and returns the connection to the pool.
+//
+//export duckarrow_commit_callback
+func duckarrow_commit_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.commit")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	inputSize := int(C.duckdb_data_chunk_get_size(input))
+	if inputSize == 0 {
+		return
+	}
+
+	threadID := currentThreadID()
+	connResult, open := flight.PinnedTransaction(threadID)
+	if !open {
+		setTxError(info, "duckarrow_commit", "no transaction is open on this connection")
+		return
+	}
+
+	err := connResult.Client.Commit(ctx)
+	flight.UnpinTransaction(threadID)
+	if connResult.IsPooled {
+		flight.ReleaseConnection(connResult.Lease)
+	} else {
+		connResult.Client.Close()
+	}
+	if err != nil {
+		opErr = err
+		setTxError(info, "duckarrow_commit", "commit failed: "+err.Error())
+		return
+	}
+
+	assignTxResult(output, inputSize, "transaction committed")
+}
+
+// duckarrow_rollback_callback is the scalar function callback for
+// duckarrow_rollback(). It rolls back the transaction pinned to the calling
+// OS thread, restores autocommit mode, and returns the connection to the
+// pool.
+//
+//export duckarrow_rollback_callback
+func duckarrow_rollback_callback(info C.duckdb_function_info, input C.duckdb_data_chunk, output C.duckdb_vector) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var opErr error
+	ctx, span := tracing.StartSpan(tracing.ContextWithTraceParent(context.Background()), "duckarrow.rollback")
+	defer func() { tracing.EndWithError(span, opErr) }()
+
+	inputSize := int(C.duckdb_data_chunk_get_size(input))
+	if inputSize == 0 {
+		return
+	}
+
+	threadID := currentThreadID()
+	connResult, open := flight.PinnedTransaction(threadID)
+	if !open {
+		setTxError(info, "duckarrow_rollback", "no transaction is open on this connection")
+		return
+	}
+
+	err := connResult.Client.Rollback(ctx)
+	flight.UnpinTransaction(threadID)
+	if connResult.IsPooled {
+		flight.ReleaseConnection(connResult.Lease)
+	} else {
+		connResult.Client.Close()
+	}
+	if err != nil {
+		opErr = err
+		setTxError(info, "duckarrow_rollback", "rollback failed: "+err.Error())
+		return
+	}
+
+	assignTxResult(output, inputSize, "transaction rolled back")
+}
+
+// registerNiladicVarcharFunction registers a scalar function taking no
+// parameters and returning VARCHAR, the shape shared by duckarrow_begin,
+// duckarrow_commit, and duckarrow_rollback.
+func registerNiladicVarcharFunction(conn duckdb.Connection, name string, fn C.duckdb_scalar_function_t) duckdb.State {
+	scalarFunc := C.duckdb_create_scalar_function()
+	defer C.duckdb_destroy_scalar_function(&scalarFunc)
+
+	nameCStr := C.CString(name)
+	defer C.free(unsafe.Pointer(nameCStr))
+	C.duckdb_scalar_function_set_name(scalarFunc, nameCStr)
+
+	varcharRetType := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+	C.duckdb_scalar_function_set_return_type(scalarFunc, varcharRetType)
+	C.duckdb_destroy_logical_type(&varcharRetType)
+
+	C.duckdb_scalar_function_set_function(scalarFunc, fn)
+
+	return duckdb.State(C.duckdb_register_scalar_function(
+		C.duckdb_connection(conn.Ptr), scalarFunc))
+}
+
+// RegisterDuckArrowBeginFunction registers the duckarrow_begin() scalar
+// function, which opens a transaction pinned to the calling connection.
+//
+// Usage in SQL:
+//
+//	SELECT duckarrow_begin();
+//	SELECT duckarrow_execute('INSERT INTO t VALUES (1)');
+//	SELECT duckarrow_commit();
+func RegisterDuckArrowBeginFunction(conn duckdb.Connection) duckdb.State {
+	return registerNiladicVarcharFunction(conn, "duckarrow_begin",
+		C.duckdb_scalar_function_t(C.duckarrow_begin_callback))
+}
+
+// RegisterDuckArrowCommitFunction registers the duckarrow_commit() scalar
+// function, which commits the transaction duckarrow_begin opened on the
+// calling connection.
+func RegisterDuckArrowCommitFunction(conn duckdb.Connection) duckdb.State {
+	return registerNiladicVarcharFunction(conn, "duckarrow_commit",
+		C.duckdb_scalar_function_t(C.duckarrow_commit_callback))
+}
+
+// RegisterDuckArrowRollbackFunction registers the duckarrow_rollback()
+// scalar function, which rolls back the transaction duckarrow_begin opened
+// on the calling connection.
+func RegisterDuckArrowRollbackFunction(conn duckdb.Connection) duckdb.State {
+	return registerNiladicVarcharFunction(conn, "duckarrow_rollback",
+		C.duckdb_scalar_function_t(C.duckarrow_rollback_callback))
+}